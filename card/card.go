@@ -47,22 +47,33 @@ const (
 	Black Color = "BLACK"
 )
 
-// Card represents a playing card with a suit, rank, and color
+// Card represents a playing card with a suit, rank, and color.
+// Cards dealt from a deck also carry an instance ID that tells apart
+// copies of the same face; a card without one stands for its face value.
 type Card struct {
 	Suit  Suit  `json:"suit"`
 	Rank  Rank  `json:"rank"`
 	Color Color `json:"color"`
+	ID    int   `json:"id,omitempty"` // Instance ID, unique within a game (0 for a face value)
 }
 
-// NewCard creates a new card with the given suit and rank, and automatically assigns the color
-func NewCard(suit Suit, rank Rank) Card {
-	var color Color
-
+// SuitColor returns the color of the given standard suit
+func SuitColor(suit Suit) Color {
 	switch suit {
 	case Hearts, Diamonds:
-		color = Red
+		return Red
 	case Spades, Clubs:
-		color = Black
+		return Black
+	}
+	return ""
+}
+
+// NewCard creates a new card with the given suit and rank, and automatically assigns the color.
+// Jokers are easier to create with NewRedJoker, NewBlackJoker or Parse("JKR").
+func NewCard(suit Suit, rank Rank) Card {
+	color := SuitColor(suit)
+
+	switch suit {
 	case Joker:
 		// For Joker, we need to specify a color explicitly
 		if rank == "RED" {
@@ -107,6 +118,21 @@ func (c Card) String() string {
 	return fmt.Sprintf("%s of %s", c.Rank, c.Suit)
 }
 
+// Face returns the card without its instance ID
+func (c Card) Face() Card {
+	c.ID = 0
+	return c
+}
+
+// Matches returns true if the card designates the other one: both have the
+// same face and, when both carry an instance ID, the same instance
+func (c Card) Matches(other Card) bool {
+	if c.ID != 0 && other.ID != 0 && c.ID != other.ID {
+		return false
+	}
+	return c.Face() == other.Face()
+}
+
 // MarshalJSON provides custom JSON marshaling
 func (c Card) MarshalJSON() ([]byte, error) {
 	type Alias Card
@@ -124,27 +150,28 @@ func (c Card) IsJoker() bool {
 	return c.Suit == Joker
 }
 
-// IsWildCard returns true if the card is a wild card (7 or Joker)
+// IsWildCard returns true if the card is a wild card (7 or Joker) under the default rules.
+// Games with house rules should ask their rules.Ruleset instead.
 func (c Card) IsWildCard() bool {
 	return c.Rank == Seven || c.IsJoker()
 }
 
-// IsTransparent returns true if the card is transparent (rank 2)
+// IsTransparent returns true if the card is transparent (rank 2) under the default rules
 func (c Card) IsTransparent() bool {
 	return c.Rank == Two
 }
 
-// IsSkip returns true if the card skips the next player (Ace)
+// IsSkip returns true if the card skips the next player (Ace) under the default rules
 func (c Card) IsSkip() bool {
 	return c.Rank == Ace
 }
 
-// IsSuitChanger returns true if the card can change the suit (Jack)
+// IsSuitChanger returns true if the card can change the suit (Jack) under the default rules
 func (c Card) IsSuitChanger() bool {
 	return c.Rank == Jack
 }
 
-// GetDrawPenalty returns the number of cards to draw as penalty for this card
+// GetDrawPenalty returns the number of cards to draw as penalty for this card under the default rules
 func (c Card) GetDrawPenalty() int {
 	if c.Rank == Seven {
 		return 2
@@ -153,4 +180,4 @@ func (c Card) GetDrawPenalty() int {
 		return 4
 	}
 	return 0
-} 
\ No newline at end of file
+}
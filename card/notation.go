@@ -0,0 +1,133 @@
+package card
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ErrInvalidNotation is returned when a card cannot be parsed
+var ErrInvalidNotation = errors.New("invalid card notation")
+
+// Joker notations
+const (
+	RedJokerNotation   = "JKR"
+	BlackJokerNotation = "JKB"
+)
+
+// rankNotations maps each rank to its short notation
+var rankNotations = map[Rank]string{
+	Ace:   "A",
+	Two:   "2",
+	Three: "3",
+	Four:  "4",
+	Five:  "5",
+	Six:   "6",
+	Seven: "7",
+	Eight: "8",
+	Nine:  "9",
+	Ten:   "10",
+	Jack:  "J",
+	Queen: "Q",
+	King:  "K",
+}
+
+// suitNotations maps each standard suit to its short notation
+var suitNotations = map[Suit]string{
+	Spades:   "S",
+	Hearts:   "H",
+	Diamonds: "D",
+	Clubs:    "C",
+}
+
+// suitSymbols maps each standard suit to its Unicode symbol
+var suitSymbols = map[Suit]string{
+	Spades:   "♠",
+	Hearts:   "♥",
+	Diamonds: "♦",
+	Clubs:    "♣",
+}
+
+// Short returns the compact notation of the card, such as "7H", "10C" or
+// "JKR" for the red joker
+func (c Card) Short() string {
+	return c.short(suitNotations)
+}
+
+// ShortUnicode returns the compact notation of the card with a Unicode suit
+// symbol, such as "7♥". Jokers are written as in Short.
+func (c Card) ShortUnicode() string {
+	return c.short(suitSymbols)
+}
+
+// short formats the card with the given suit notations
+func (c Card) short(suits map[Suit]string) string {
+	if c.IsJoker() {
+		if c.Color == Red {
+			return RedJokerNotation
+		}
+		return BlackJokerNotation
+	}
+	return rankNotations[c.Rank] + suits[c.Suit]
+}
+
+// Parse reads a card in compact notation: a rank (A, 2-10, J, Q, K) followed
+// by a suit letter (S, H, D, C) or symbol (♠, ♥, ♦, ♣), or JKR/JKB for the
+// jokers. Letters are case-insensitive. The card has no instance ID.
+func Parse(s string) (Card, error) {
+	notation := strings.ToUpper(strings.TrimSpace(s))
+
+	switch notation {
+	case RedJokerNotation:
+		return NewRedJoker(), nil
+	case BlackJokerNotation:
+		return NewBlackJoker(), nil
+	}
+
+	for suit, letter := range suitNotations {
+		rankNotation, ok := strings.CutSuffix(notation, letter)
+		if !ok {
+			rankNotation, ok = strings.CutSuffix(notation, suitSymbols[suit])
+		}
+		if !ok {
+			continue
+		}
+
+		for rank, n := range rankNotations {
+			if n == rankNotation {
+				return NewCard(suit, rank), nil
+			}
+		}
+	}
+
+	return Card{}, fmt.Errorf("%w: %q", ErrInvalidNotation, s)
+}
+
+// ParseHand reads cards in compact notation separated by spaces or commas,
+// such as "7H JS 2C"
+func ParseHand(s string) ([]Card, error) {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+
+	cards := make([]Card, 0, len(fields))
+	for _, field := range fields {
+		c, err := Parse(field)
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, c)
+	}
+
+	return cards, nil
+}
+
+// FormatHand writes cards in compact notation separated by spaces
+func FormatHand(cards []Card) string {
+	notations := make([]string, len(cards))
+	for i, c := range cards {
+		notations[i] = c.Short()
+	}
+	return strings.Join(notations, " ")
+}
@@ -12,26 +12,43 @@ type Deck struct {
 	Cards []card.Card
 }
 
-// New creates a new standard deck of cards (52 cards + 2 jokers)
+// Size is the number of cards in a standard deck
+const Size = 54
+
+// New creates a new standard deck of cards (52 cards + 2 jokers), numbered
+// with instance IDs from 1 in deck order
 func New() *Deck {
-	cards := make([]card.Card, 0, 54)
+	return NewShoe(1)
+}
+
+// NewShoe creates a shoe of n standard decks, one after the other, numbered
+// with instance IDs from 1. Fewer than one deck makes a single deck.
+func NewShoe(n int) *Deck {
+	n = max(n, 1)
+	cards := make([]card.Card, 0, n*Size)
 
-	// Add all standard cards
 	suits := []card.Suit{card.Spades, card.Hearts, card.Diamonds, card.Clubs}
 	ranks := []card.Rank{
 		card.Ace, card.Two, card.Three, card.Four, card.Five, card.Six, card.Seven,
 		card.Eight, card.Nine, card.Ten, card.Jack, card.Queen, card.King,
 	}
 
-	for _, suit := range suits {
-		for _, rank := range ranks {
-			cards = append(cards, card.NewCard(suit, rank))
+	for i := 0; i < n; i++ {
+		// Add all standard cards
+		for _, suit := range suits {
+			for _, rank := range ranks {
+				cards = append(cards, card.NewCard(suit, rank))
+			}
 		}
+
+		// Add jokers
+		cards = append(cards, card.NewRedJoker())
+		cards = append(cards, card.NewBlackJoker())
 	}
 
-	// Add jokers
-	cards = append(cards, card.NewRedJoker())
-	cards = append(cards, card.NewBlackJoker())
+	for i := range cards {
+		cards[i].ID = i + 1
+	}
 
 	return &Deck{Cards: cards}
 }
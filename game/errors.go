@@ -0,0 +1,20 @@
+package game
+
+import (
+	"errors"
+)
+
+// Sentinel errors returned by history navigation
+var (
+	ErrNothingToUndo     = errors.New("nothing to undo")
+	ErrNothingToRedo     = errors.New("nothing to redo")
+	ErrHistoryOutOfRange = errors.New("history position out of range")
+)
+
+// Sentinel errors returned by matches
+var (
+	ErrNoMatchEnd      = errors.New("a match needs a target score or a round limit")
+	ErrRoundInProgress = errors.New("round is still in progress")
+	ErrMatchOver       = errors.New("match is over")
+	ErrNoCurrentRound  = errors.New("match has no current round")
+)
@@ -2,22 +2,41 @@
 package game
 
 import (
-	"errors"
+	"time"
 
 	"github.com/djoufson/check-games-engine/card"
-	"github.com/djoufson/check-games-engine/player"
+	"github.com/djoufson/check-games-engine/rules"
 	"github.com/djoufson/check-games-engine/state"
 )
 
 // Game represents a check-game
 type Game struct {
-	state *state.State
+	state    *state.State
+	handlers []EventHandler
+	events   []state.Event
+	past     []historyEntry // Applied moves, oldest first
+	future   []historyEntry // Undone moves, most recently undone last
 }
 
+// historyEntry records a move together with the state it was applied to
+type historyEntry struct {
+	move   state.Move
+	before *state.State
+	after  *state.State
+}
+
+// EventHandler is called for every event produced by a move
+type EventHandler func(state.Event)
+
 // Options defines configurable options for a new game
 type Options struct {
-	InitialCards int   // Number of cards dealt to each player at start
-	RandomSeed   int64 // Seed for RNG (useful for deterministic tests)
+	InitialCards int                // Number of cards dealt to each player at start (0 uses the ruleset's)
+	RandomSeed   int64              // Seed for RNG (useful for deterministic tests)
+	Ruleset      *rules.Ruleset     // House rules to play with (nil uses rules.Default())
+	FirstPlayer  string             // ID of the player who starts (empty means the first ID)
+	Decks        int                // Number of decks in the shoe (0 uses state.DecksFor the player count)
+	TimeControl  *state.TimeControl // Turn clocks to play with (nil for an untimed game)
+	Clock        state.Clock        // Time source of the turn clocks (nil uses the system time)
 }
 
 // State returns a snapshot of the current game state for serialization
@@ -28,7 +47,7 @@ func (g *Game) State() *state.State {
 // New creates a new game with the given player IDs and options
 func New(playerIDs []string, options *Options) (*Game, error) {
 	if len(playerIDs) < 2 {
-		return nil, errors.New("at least 2 players are required")
+		return nil, state.ErrNotEnoughPlayers
 	}
 
 	var stateOpts *state.GameOptions
@@ -36,6 +55,11 @@ func New(playerIDs []string, options *Options) (*Game, error) {
 		stateOpts = &state.GameOptions{
 			InitialCards: options.InitialCards,
 			RandomSeed:   options.RandomSeed,
+			Ruleset:      options.Ruleset,
+			FirstPlayer:  options.FirstPlayer,
+			TimeControl:  options.TimeControl,
+			Clock:        options.Clock,
+			Decks:        options.Decks,
 		}
 	}
 
@@ -52,7 +76,8 @@ func FromState(s *state.State) *Game {
 	return &Game{state: s}
 }
 
-// FromJSON creates a game from a JSON-serialized state
+// FromJSON creates a game from a JSON-serialized state. Corrupt or tampered
+// states are rejected with state.ErrInvalidState.
 func FromJSON(data []byte) (*Game, error) {
 	s, err := state.FromJSON(data)
 	if err != nil {
@@ -61,11 +86,26 @@ func FromJSON(data []byte) (*Game, error) {
 	return &Game{state: s}, nil
 }
 
+// ViewFor returns the game as seen by the given player, safe to send to their client
+func (g *Game) ViewFor(playerID string) (*state.View, error) {
+	return g.state.ViewFor(playerID)
+}
+
+// SpectatorView returns the game as seen by a spectator, without any hand
+func (g *Game) SpectatorView() *state.View {
+	return g.state.SpectatorView()
+}
+
 // ToJSON serializes the game state to JSON
 func (g *Game) ToJSON() ([]byte, error) {
 	return g.state.ToJSON()
 }
 
+// Rules returns the ruleset the game is played with
+func (g *Game) Rules() rules.Ruleset {
+	return g.state.Rules().Clone()
+}
+
 // CurrentPlayerID returns the ID of the player whose turn it is
 func (g *Game) CurrentPlayerID() string {
 	return g.state.CurrentPlayerID()
@@ -76,26 +116,178 @@ func (g *Game) IsPlayerTurn(playerID string) bool {
 	return g.state.CurrentPlayerID() == playerID
 }
 
+// Apply applies a move to the game and publishes the events it produced
+func (g *Game) Apply(m state.Move) error {
+	before := g.state.Clone()
+
+	err := g.state.Apply(m)
+	g.publish(g.state.DrainEvents())
+	if err != nil {
+		return err
+	}
+
+	g.past = append(g.past, historyEntry{move: m, before: before, after: g.state.Clone()})
+	g.future = nil
+
+	return nil
+}
+
+// History returns the moves applied so far, oldest first. Undone moves are not included.
+func (g *Game) History() []state.Move {
+	moves := make([]state.Move, len(g.past))
+	for i, entry := range g.past {
+		moves[i] = entry.move
+	}
+	return moves
+}
+
+// CanUndo checks if there is a move to undo
+func (g *Game) CanUndo() bool {
+	return len(g.past) > 0
+}
+
+// CanRedo checks if there is an undone move to redo
+func (g *Game) CanRedo() bool {
+	return len(g.future) > 0
+}
+
+// Undo restores the state from before the last applied move
+func (g *Game) Undo() error {
+	if !g.CanUndo() {
+		return ErrNothingToUndo
+	}
+
+	entry := g.past[len(g.past)-1]
+	g.past = g.past[:len(g.past)-1]
+	g.future = append(g.future, entry)
+	g.state = entry.before.Clone()
+
+	return nil
+}
+
+// Redo re-applies the last undone move
+func (g *Game) Redo() error {
+	if !g.CanRedo() {
+		return ErrNothingToRedo
+	}
+
+	entry := g.future[len(g.future)-1]
+	g.future = g.future[:len(g.future)-1]
+	g.past = append(g.past, entry)
+	g.state = entry.after.Clone()
+
+	return nil
+}
+
+// GoTo restores the game to the point after the given number of moves,
+// counting both applied and undone moves
+func (g *Game) GoTo(position int) error {
+	if position < 0 || position > len(g.past)+len(g.future) {
+		return ErrHistoryOutOfRange
+	}
+
+	for len(g.past) > position {
+		if err := g.Undo(); err != nil {
+			return err
+		}
+	}
+
+	for len(g.past) < position {
+		if err := g.Redo(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Subscribe registers a handler that is called for every event as it happens
+func (g *Game) Subscribe(handler EventHandler) {
+	g.handlers = append(g.handlers, handler)
+}
+
+// DrainEvents returns the events collected since the last call and clears them.
+// Events are collected even when handlers are subscribed, so a game that is
+// never drained keeps every event of its history.
+func (g *Game) DrainEvents() []state.Event {
+	events := g.events
+	g.events = nil
+	return events
+}
+
+// publish collects the events and forwards them to the subscribers
+func (g *Game) publish(events []state.Event) {
+	for _, e := range events {
+		g.events = append(g.events, e)
+		for _, handler := range g.handlers {
+			handler(e)
+		}
+	}
+}
+
 // PlayCard plays a card from the current player's hand
 func (g *Game) PlayCard(playerID string, c card.Card) error {
-	return g.state.PlayCard(playerID, c)
+	return g.Apply(state.NewPlayCardMove(playerID, c))
+}
+
+// PlayCards plays several cards of the same rank from the current player's hand at once.
+// It is only allowed when the ruleset enables multi-play.
+func (g *Game) PlayCards(playerID string, cards []card.Card) error {
+	return g.Apply(state.NewPlayCardsMove(playerID, cards))
 }
 
 // DrawCard causes the current player to draw a card
 func (g *Game) DrawCard(playerID string) error {
-	return g.state.DrawCard(playerID)
+	return g.Apply(state.NewDrawCardMove(playerID))
 }
 
 // ChangeSuit changes the active suit (used after playing a Jack)
 func (g *Game) ChangeSuit(playerID string, newSuit card.Suit) error {
-	return g.state.ChangeSuit(playerID, newSuit)
+	return g.Apply(state.NewChangeSuitMove(playerID, newSuit))
+}
+
+// AnnounceCheck announces "Check!" for the player's last card
+func (g *Game) AnnounceCheck(playerID string) error {
+	return g.Apply(state.NewAnnounceCheckMove(playerID))
+}
+
+// ChallengeCheck challenges a player who reached one card without announcing "Check!"
+func (g *Game) ChallengeCheck(challengerID, targetID string) error {
+	return g.Apply(state.NewChallengeCheckMove(challengerID, targetID))
+}
+
+// Timeout applies the timeout action to the current player once their time is up
+func (g *Game) Timeout(playerID string) error {
+	return g.Apply(state.NewTimeoutMove(playerID))
+}
+
+// Forfeit takes a player who quits or disconnects for good out of the game as a loss
+func (g *Game) Forfeit(playerID string) error {
+	return g.Apply(state.NewForfeitMove(playerID))
+}
+
+// SetClock sets the time source of the turn clocks
+func (g *Game) SetClock(c state.Clock) {
+	g.state.SetClock(c)
+}
+
+// TurnDeadline returns when the current player runs out of time. The second
+// return value is false when the game is not timed.
+func (g *Game) TurnDeadline() (time.Time, bool) {
+	return g.state.TurnDeadline()
+}
+
+// TimeLeft returns the time the player has left in their bank. The second
+// return value is false when the game has no bank.
+func (g *Game) TimeLeft(playerID string) (time.Duration, bool) {
+	return g.state.TimeLeft(playerID)
 }
 
 // GetPlayerHand returns the cards in the specified player's hand
 func (g *Game) GetPlayerHand(playerID string) ([]card.Card, error) {
 	player := g.state.FindPlayerByID(playerID)
 	if player == nil {
-		return nil, errors.New("player not found")
+		return nil, state.ErrPlayerNotFound
 	}
 
 	// Return a copy of the hand to prevent modification
@@ -105,18 +297,26 @@ func (g *Game) GetPlayerHand(playerID string) ([]card.Card, error) {
 	return hand, nil
 }
 
-// GetPlayableCards returns the cards in the player's hand that can be played
+// GetPlayableCards returns the cards in the player's hand that can be played.
+// The list is empty when it is not the player's turn.
 func (g *Game) GetPlayableCards(playerID string) ([]card.Card, error) {
-	if !g.IsPlayerTurn(playerID) {
-		return nil, errors.New("not player's turn")
+	if g.state.FindPlayerByID(playerID) == nil {
+		return nil, state.ErrPlayerNotFound
 	}
 
-	player := g.state.FindPlayerByID(playerID)
-	if player == nil {
-		return nil, errors.New("player not found")
+	playable := make([]card.Card, 0)
+	for _, m := range g.state.LegalMoves(playerID) {
+		if m.Type == state.MovePlayCard {
+			playable = append(playable, *m.Card)
+		}
 	}
 
-	return player.GetPlayableCards(g.state.TopCard, g.state.InAttackChain), nil
+	return playable, nil
+}
+
+// LegalMoves returns every move the player may make right now
+func (g *Game) LegalMoves(playerID string) []state.Move {
+	return g.state.LegalMoves(playerID)
 }
 
 // GetTopCard returns the current top card
@@ -124,11 +324,27 @@ func (g *Game) GetTopCard() card.Card {
 	return g.state.TopCard
 }
 
+// GetEffectiveTopCard returns the card that actually has to be matched,
+// looking through any transparent 2s on top of the discard pile
+func (g *Game) GetEffectiveTopCard() card.Card {
+	return g.state.EffectiveTopCard()
+}
+
 // GetLastActiveSuit returns the last active suit (important for Jack effects)
 func (g *Game) GetLastActiveSuit() card.Suit {
 	return g.state.LastActiveSuit
 }
 
+// GetActiveSuit returns the suit the next card must follow
+func (g *Game) GetActiveSuit() card.Suit {
+	return g.state.ActiveSuit()
+}
+
+// GetDirection returns the current direction of play
+func (g *Game) GetDirection() state.Direction {
+	return g.state.Direction
+}
+
 // IsGameOver checks if the game is over
 func (g *Game) IsGameOver() bool {
 	return g.state.IsGameOver()
@@ -139,6 +355,18 @@ func (g *Game) GetWinners() []string {
 	return g.state.GetWinner()
 }
 
+// Standings returns the players who finished so far with their place and
+// the turn they finished on, best first. Once the game is over the last
+// entry is the loser.
+func (g *Game) Standings() []state.Placement {
+	return g.state.Standings()
+}
+
+// ForfeitedHand returns the cards the player gave up when they forfeited
+func (g *Game) ForfeitedHand(playerID string) []card.Card {
+	return g.state.ForfeitedHand(playerID)
+}
+
 // GetLoser returns the ID of the player who lost
 func (g *Game) GetLoser() string {
 	return g.state.GetLoser()
@@ -149,6 +377,11 @@ func (g *Game) IsPlayerActive(playerID string) bool {
 	return g.state.IsPlayerActive(playerID)
 }
 
+// GetPhase returns the step of the turn the game is waiting for
+func (g *Game) GetPhase() state.Phase {
+	return g.state.CurrentPhase()
+}
+
 // IsInAttackChain checks if the game is in an attack chain
 func (g *Game) IsInAttackChain() bool {
 	return g.state.InAttackChain
@@ -166,25 +399,8 @@ func (g *Game) GetPlayerCount() int {
 
 // ValidateMove checks if a move is valid without modifying the game state
 func (g *Game) ValidateMove(playerID string, c card.Card) (bool, error) {
-	// Check if it's the player's turn
-	if !g.IsPlayerTurn(playerID) {
-		return false, errors.New("not player's turn")
-	}
-
-	// Find the player
-	p := g.state.FindPlayerByID(playerID)
-	if p == nil {
-		return false, errors.New("player not found")
-	}
-
-	// Check if the player has the card
-	if !p.HasCard(c) {
-		return false, errors.New("card not in hand")
-	}
-
-	// Check if the play is valid according to game rules
-	if !player.CanPlayCardOn(c, g.state.TopCard, g.state.InAttackChain) {
-		return false, errors.New("invalid move")
+	if err := g.state.CheckMove(state.NewPlayCardMove(playerID, c)); err != nil {
+		return false, err
 	}
 
 	return true, nil
@@ -0,0 +1,307 @@
+package game
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/djoufson/check-games-engine/card"
+	"github.com/djoufson/check-games-engine/rules"
+	"github.com/djoufson/check-games-engine/state"
+)
+
+// PointTable gives the penalty points of the cards left in a hand
+type PointTable struct {
+	Ranks map[card.Rank]int `json:"ranks"` // Points of each rank
+	Joker int               `json:"joker"` // Points of a joker
+}
+
+// DefaultPointTable returns the standard point table: number cards are worth
+// their face value, Queens and Kings 10, special cards 20 and jokers 50
+func DefaultPointTable() PointTable {
+	return PointTable{
+		Ranks: map[card.Rank]int{
+			card.Ace:   20,
+			card.Two:   20,
+			card.Three: 3,
+			card.Four:  4,
+			card.Five:  5,
+			card.Six:   6,
+			card.Seven: 20,
+			card.Eight: 8,
+			card.Nine:  9,
+			card.Ten:   10,
+			card.Jack:  20,
+			card.Queen: 10,
+			card.King:  10,
+		},
+		Joker: 50,
+	}
+}
+
+// PointsOf returns the total points of the given cards
+func (t PointTable) PointsOf(cards []card.Card) int {
+	total := 0
+	for _, c := range cards {
+		if c.IsJoker() {
+			total += t.Joker
+		} else {
+			total += t.Ranks[c.Rank]
+		}
+	}
+	return total
+}
+
+// MatchOptions defines configurable options for a new match
+type MatchOptions struct {
+	InitialCards int            `json:"initial_cards,omitempty"` // Number of cards dealt each round (0 uses the ruleset's)
+	RandomSeed   int64          `json:"random_seed"`             // Seed of the first round; each round adds one (0 draws a random seed)
+	Ruleset      *rules.Ruleset `json:"ruleset,omitempty"`       // House rules to play with (nil uses rules.Default())
+	Decks        int            `json:"decks,omitempty"`         // Number of decks in the shoe (0 uses state.DecksFor the player count)
+	Points       *PointTable    `json:"points,omitempty"`        // Card points (nil uses DefaultPointTable())
+	TargetScore  int            `json:"target_score,omitempty"`  // The match ends once a player reaches this score (0 for no target)
+	MaxRounds    int            `json:"max_rounds,omitempty"`    // The match ends after this many rounds (0 for no limit)
+}
+
+// RoundResult records the outcome of a finished round
+type RoundResult struct {
+	Round       int               `json:"round"`
+	FirstPlayer string            `json:"first_player"`
+	Standings   []state.Placement `json:"standings"`
+	Points      map[string]int    `json:"points"` // Points charged to the loser and to players who forfeited
+}
+
+// Match plays consecutive rounds with the same seats. At the end of each
+// round the loser scores the points of the cards left in their hand, and
+// players who forfeited score the points of the hand they gave up. The
+// lowest total wins once a player reaches the target score or the round
+// limit is reached.
+type Match struct {
+	playerIDs []string
+	options   MatchOptions
+	scores    map[string]int
+	rounds    []RoundResult
+	game      *Game
+}
+
+// NewMatch creates a match with the given seats and starts its first round
+func NewMatch(playerIDs []string, options *MatchOptions) (*Match, error) {
+	if len(playerIDs) < 2 {
+		return nil, state.ErrNotEnoughPlayers
+	}
+
+	var opts MatchOptions
+	if options != nil {
+		opts = *options
+	}
+	if opts.TargetScore <= 0 && opts.MaxRounds <= 0 {
+		return nil, ErrNoMatchEnd
+	}
+	if opts.RandomSeed == 0 {
+		// Draw the seed once; it is saved with the match so a resumed match deals the same rounds
+		opts.RandomSeed = time.Now().UnixNano()
+	}
+
+	m := &Match{
+		playerIDs: slices.Clone(playerIDs),
+		options:   opts,
+		scores:    make(map[string]int, len(playerIDs)),
+	}
+	for _, id := range playerIDs {
+		m.scores[id] = 0
+	}
+
+	if err := m.startRound(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Game returns the game of the current round
+func (m *Match) Game() *Game {
+	return m.game
+}
+
+// Round returns the number of the current round, counting from 1
+func (m *Match) Round() int {
+	return len(m.rounds) + 1
+}
+
+// Rounds returns the results of the finished rounds
+func (m *Match) Rounds() []RoundResult {
+	return slices.Clone(m.rounds)
+}
+
+// Scores returns the total points of each player
+func (m *Match) Scores() map[string]int {
+	return maps.Clone(m.scores)
+}
+
+// FirstPlayer returns the player who starts the given round. The first seat
+// moves one place clockwise every round.
+func (m *Match) FirstPlayer(round int) string {
+	return m.playerIDs[(round-1)%len(m.playerIDs)]
+}
+
+// FinishRound scores the finished round and, unless the match is over,
+// starts the next one
+func (m *Match) FinishRound() error {
+	if m.IsOver() {
+		return ErrMatchOver
+	}
+
+	if !m.game.IsGameOver() {
+		return ErrRoundInProgress
+	}
+
+	points := m.pointTable()
+	result := RoundResult{
+		Round:       m.Round(),
+		FirstPlayer: m.FirstPlayer(m.Round()),
+		Standings:   m.game.Standings(),
+		Points:      make(map[string]int),
+	}
+	loser := m.game.GetLoser()
+	for _, placement := range result.Standings {
+		var hand []card.Card
+		switch {
+		case placement.Forfeited:
+			hand = m.game.ForfeitedHand(placement.PlayerID)
+		case placement.PlayerID == loser:
+			var err error
+			if hand, err = m.game.GetPlayerHand(loser); err != nil {
+				return err
+			}
+		default:
+			continue
+		}
+		result.Points[placement.PlayerID] = points.PointsOf(hand)
+		m.scores[placement.PlayerID] += result.Points[placement.PlayerID]
+	}
+	m.rounds = append(m.rounds, result)
+
+	if m.IsOver() {
+		return nil
+	}
+
+	return m.startRound()
+}
+
+// IsOver checks if a player reached the target score or the round limit was played
+func (m *Match) IsOver() bool {
+	if m.options.MaxRounds > 0 && len(m.rounds) >= m.options.MaxRounds {
+		return true
+	}
+
+	if m.options.TargetScore > 0 {
+		for _, score := range m.scores {
+			if score >= m.options.TargetScore {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Leaders returns the players with the lowest score, in seat order.
+// Once the match is over they are its winners.
+func (m *Match) Leaders() []string {
+	leaders := make([]string, 0)
+	for _, id := range m.playerIDs {
+		switch {
+		case len(leaders) == 0 || m.scores[id] < m.scores[leaders[0]]:
+			leaders = []string{id}
+		case m.scores[id] == m.scores[leaders[0]]:
+			leaders = append(leaders, id)
+		}
+	}
+	return leaders
+}
+
+// startRound deals a new game for the current round
+func (m *Match) startRound() error {
+	round := m.Round()
+	seed := m.options.RandomSeed + int64(round-1)
+
+	g, err := New(m.playerIDs, &Options{
+		InitialCards: m.options.InitialCards,
+		RandomSeed:   seed,
+		Ruleset:      m.options.Ruleset,
+		Decks:        m.options.Decks,
+		FirstPlayer:  m.FirstPlayer(round),
+	})
+	if err != nil {
+		return err
+	}
+
+	m.game = g
+	return nil
+}
+
+// pointTable returns the point table in use
+func (m *Match) pointTable() PointTable {
+	if m.options.Points != nil {
+		return *m.options.Points
+	}
+	return DefaultPointTable()
+}
+
+// matchJSON is the serialized form of a match
+type matchJSON struct {
+	PlayerIDs []string        `json:"player_ids"`
+	Options   MatchOptions    `json:"options"`
+	Scores    map[string]int  `json:"scores"`
+	Rounds    []RoundResult   `json:"rounds"`
+	Game      json.RawMessage `json:"game"` // State of the current round, as written by State.ToJSON
+}
+
+// ToJSON serializes the match, including the state of the current round
+func (m *Match) ToJSON() ([]byte, error) {
+	game, err := m.game.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(matchJSON{
+		PlayerIDs: m.playerIDs,
+		Options:   m.options,
+		Scores:    m.scores,
+		Rounds:    m.rounds,
+		Game:      game,
+	})
+}
+
+// MatchFromJSON restores a match serialized with ToJSON
+func MatchFromJSON(data []byte) (*Match, error) {
+	var decoded matchJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, err
+	}
+
+	if len(decoded.PlayerIDs) < 2 {
+		return nil, state.ErrNotEnoughPlayers
+	}
+	if len(decoded.Game) == 0 || string(decoded.Game) == "null" {
+		return nil, ErrNoCurrentRound
+	}
+	g, err := FromJSON(decoded.Game)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Match{
+		playerIDs: decoded.PlayerIDs,
+		options:   decoded.Options,
+		scores:    decoded.Scores,
+		rounds:    decoded.Rounds,
+		game:      g,
+	}
+	if m.scores == nil {
+		m.scores = make(map[string]int)
+	}
+
+	return m, nil
+}
@@ -3,6 +3,7 @@ package player
 
 import (
 	"github.com/djoufson/check-games-engine/card"
+	"github.com/djoufson/check-games-engine/rules"
 )
 
 // Player represents a player in the game
@@ -29,40 +30,96 @@ func (p *Player) AddCardsToHand(cards []card.Card) {
 	p.Hand = append(p.Hand, cards...)
 }
 
-// RemoveFromHand removes a card from the player's hand and returns it
+// RemoveFromHand removes a card from the player's hand and returns the
+// removed instance. The card may be given by face value or by instance.
 // Returns false if the card is not in the hand
 func (p *Player) RemoveFromHand(c card.Card) (card.Card, bool) {
 	for i, handCard := range p.Hand {
-		if handCard.Suit == c.Suit && handCard.Rank == c.Rank && handCard.Color == c.Color {
+		if c.Matches(handCard) {
 			// Remove the card from the hand
 			p.Hand = append(p.Hand[:i], p.Hand[i+1:]...)
-			return c, true
+			return handCard, true
 		}
 	}
 	return card.Card{}, false
 }
 
+// RemoveCardsFromHand removes several cards from the player's hand and
+// returns the removed instances in the same order. Cards given by instance
+// are matched before cards given by face value. Returns false, leaving the
+// hand unchanged, if any card is not in the hand
+func (p *Player) RemoveCardsFromHand(cards []card.Card) ([]card.Card, bool) {
+	if !p.HasCards(cards) {
+		return nil, false
+	}
+
+	removed := make([]card.Card, len(cards))
+	for _, byInstance := range []bool{true, false} {
+		for i, c := range cards {
+			if (c.ID != 0) == byInstance {
+				removed[i], _ = p.RemoveFromHand(c)
+			}
+		}
+	}
+	return removed, true
+}
+
 // HasCard returns true if the player has the specified card in their hand
 func (p *Player) HasCard(c card.Card) bool {
 	for _, handCard := range p.Hand {
-		if handCard.Suit == c.Suit && handCard.Rank == c.Rank && handCard.Color == c.Color {
+		if c.Matches(handCard) {
 			return true
 		}
 	}
 	return false
 }
 
+// HasCards returns true if the player holds all the specified cards,
+// counting a card listed several times once per copy. Cards given by
+// instance are matched before cards given by face value.
+func (p *Player) HasCards(cards []card.Card) bool {
+	used := make([]bool, len(p.Hand))
+	for _, byInstance := range []bool{true, false} {
+		for _, c := range cards {
+			if (c.ID != 0) != byInstance {
+				continue
+			}
+
+			found := false
+			for i, handCard := range p.Hand {
+				if !used[i] && c.Matches(handCard) {
+					used[i] = true
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // HasMatchingCard returns true if the player has a card that matches the specified
-// card by color, rank, or suit according to the game rules
+// card by color, rank, or suit according to the default rules
 func (p *Player) HasMatchingCard(c card.Card, includeWildCards bool) bool {
+	return p.HasMatchingCardUnder(rules.Default(), c, includeWildCards)
+}
+
+// HasMatchingCardUnder returns true if the player has a card that matches the
+// specified card by color, rank, or suit under the given ruleset. Transparent
+// cards match anything, and includeWildCards also lets attack cards match
+// other attack cards.
+func (p *Player) HasMatchingCardUnder(rs rules.Ruleset, c card.Card, includeWildCards bool) bool {
 	for _, handCard := range p.Hand {
-		// Transparent card (2) can be played on any card
-		if handCard.IsTransparent() {
+		// Transparent card can be played on any card
+		if rs.IsTransparent(handCard) {
 			return true
 		}
 
 		// Wild cards can be played on other wild cards
-		if includeWildCards && handCard.IsWildCard() && c.IsWildCard() {
+		if includeWildCards && rs.IsAttack(handCard) && rs.IsAttack(c) {
 			return true
 		}
 
@@ -83,12 +140,23 @@ func (p *Player) HasMatchingCard(c card.Card, includeWildCards bool) bool {
 	return false
 }
 
-// GetPlayableCards returns a list of cards that the player can play on the specified card
+// GetPlayableCards returns a list of cards that the player can play on the specified
+// card according to the default rules
 func (p *Player) GetPlayableCards(c card.Card, inAttackChain bool) []card.Card {
+	return p.PlayableCardsUnder(rules.Default(), c, "", inAttackChain)
+}
+
+// PlayableCardsUnder returns a list of cards that the player can play on the specified card
+// under the given ruleset. activeSuit is the suit in force (e.g. declared with a Jack);
+// empty means the card's own suit. A last card the ruleset forbids finishing on is not playable.
+func (p *Player) PlayableCardsUnder(rs rules.Ruleset, c card.Card, activeSuit card.Suit, inAttackChain bool) []card.Card {
 	playable := make([]card.Card, 0)
 
 	for _, handCard := range p.Hand {
-		if CanPlayCardOn(handCard, c, inAttackChain) {
+		if len(p.Hand) == 1 && !rs.CanFinishOn(handCard) {
+			continue
+		}
+		if CanPlayCardUnder(rs, handCard, c, activeSuit, inAttackChain) {
 			playable = append(playable, handCard)
 		}
 	}
@@ -96,29 +164,43 @@ func (p *Player) GetPlayableCards(c card.Card, inAttackChain bool) []card.Card {
 	return playable
 }
 
-// CanPlayCardOn checks if a card can be played on another card according to the rules
+// CanPlayCardOn checks if a card can be played on another card according to the default rules
 func CanPlayCardOn(playedCard, topCard card.Card, inAttackChain bool) bool {
+	return CanPlayCardUnder(rules.Default(), playedCard, topCard, "", inAttackChain)
+}
+
+// CanPlayCardUnder checks if a card can be played on another card according to the ruleset.
+// activeSuit is the suit that must be followed; when it differs from the top card's
+// suit (after a Jack declaration) it replaces the top card's suit and color for matching.
+func CanPlayCardUnder(rs rules.Ruleset, playedCard, topCard card.Card, activeSuit card.Suit, inAttackChain bool) bool {
 	// In an attack chain, only wild cards can be played on wild cards
 	if inAttackChain {
-		// If we're in an attack chain, only wild cards can respond to wild cards
-		if topCard.IsWildCard() {
-			return playedCard.IsWildCard()
+		// If we're in an attack chain, only wild cards can respond to wild cards,
+		// and only when the ruleset lets attacks stack
+		if rs.StackAttacks && rs.IsAttack(topCard) {
+			return rs.IsAttack(playedCard)
 		}
 		return false
 	}
 
 	// Transparent card (2) and Suit changer (Jack) can be played on any card except during attack chain
-	if playedCard.IsTransparent() || playedCard.IsSuitChanger() {
+	if rs.IsTransparent(playedCard) || rs.IsSuitChanger(playedCard) {
 		return true
 	}
 
 	// Wild cards can be played on other wild cards (7 or Joker)
-	if playedCard.IsWildCard() && topCard.IsWildCard() {
+	if rs.IsAttack(playedCard) && rs.IsAttack(topCard) {
 		return true
 	}
 
+	// A declared suit overrides the suit and color of the top card
+	suit, color := topCard.Suit, topCard.Color
+	if activeSuit != "" && activeSuit != topCard.Suit {
+		suit, color = activeSuit, card.SuitColor(activeSuit)
+	}
+
 	// Regular matching: same suit or same rank
-	if playedCard.Suit == topCard.Suit {
+	if playedCard.Suit == suit {
 		return true
 	}
 
@@ -127,7 +209,7 @@ func CanPlayCardOn(playedCard, topCard card.Card, inAttackChain bool) bool {
 	}
 
 	// Joker color matching
-	if playedCard.IsJoker() && topCard.Color == playedCard.Color {
+	if playedCard.IsJoker() && color == playedCard.Color {
 		return true
 	}
 
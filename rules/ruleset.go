@@ -0,0 +1,164 @@
+// Package rules defines the configurable card semantics of the check-game engine.
+package rules
+
+import (
+	"errors"
+	"maps"
+
+	"github.com/djoufson/check-games-engine/card"
+)
+
+// Effect represents the special effect a card has when played
+type Effect string
+
+// Card effects
+const (
+	None        Effect = ""
+	Skip        Effect = "SKIP"        // Skips the next player
+	Attack      Effect = "ATTACK"      // Starts or escalates an attack chain
+	SuitChange  Effect = "SUIT_CHANGE" // Lets the player declare a new suit
+	Transparent Effect = "TRANSPARENT" // Can be played on anything and is looked through
+	Reverse     Effect = "REVERSE"     // Reverses the direction of play
+)
+
+// FinishRule decides what happens when a player goes out on a special card
+type FinishRule string
+
+// Finish rules
+const (
+	FinishAllowed   FinishRule = ""          // Going out on a special card is allowed
+	FinishForbidden FinishRule = "FORBIDDEN" // The last card may not be a special card
+	FinishPenalty   FinishRule = "PENALTY"   // Going out on a special card draws a penalty instead
+)
+
+// Ruleset assigns effects to ranks and holds the tunable parts of the rules.
+// Jokers are always attack cards; only their penalty can be changed.
+type Ruleset struct {
+	Effects       map[card.Rank]Effect `json:"effects"`                  // Effect of each special rank
+	Penalties     map[card.Rank]int    `json:"penalties"`                // Cards to draw for each attacking rank
+	JokerPenalty  int                  `json:"joker_penalty"`            // Cards to draw for a joker
+	StackAttacks  bool                 `json:"stack_attacks"`            // Whether an attack can be answered with another attack
+	InitialCards  int                  `json:"initial_cards"`            // Number of cards dealt to each player at start
+	MultiPlay     bool                 `json:"multi_play"`               // Whether several cards of the same rank can be played at once
+	CheckPenalty  int                  `json:"check_penalty"`            // Cards to draw when challenged for not announcing "Check!" (0 disables challenges)
+	SpecialFinish FinishRule           `json:"special_finish,omitempty"` // What happens when going out on a special card
+	FinishPenalty int                  `json:"finish_penalty,omitempty"` // Cards to draw when going out on a special card with FinishPenalty
+}
+
+// Default returns the standard ruleset
+func Default() Ruleset {
+	return Ruleset{
+		Effects: map[card.Rank]Effect{
+			card.Ace:   Skip,
+			card.Two:   Transparent,
+			card.Seven: Attack,
+			card.Jack:  SuitChange,
+		},
+		Penalties: map[card.Rank]int{
+			card.Seven: 2,
+		},
+		JokerPenalty: 4,
+		StackAttacks: true,
+		InitialCards: 7,
+		CheckPenalty: 2,
+	}
+}
+
+// Clone returns a deep copy of the ruleset
+func (r Ruleset) Clone() Ruleset {
+	r.Effects = maps.Clone(r.Effects)
+	r.Penalties = maps.Clone(r.Penalties)
+	return r
+}
+
+// Validate checks that the ruleset is consistent
+func (r Ruleset) Validate() error {
+	if r.InitialCards <= 0 {
+		return errors.New("initial cards must be positive")
+	}
+
+	if r.JokerPenalty <= 0 {
+		return errors.New("joker penalty must be positive")
+	}
+
+	if r.CheckPenalty < 0 {
+		return errors.New("check penalty cannot be negative")
+	}
+
+	switch r.SpecialFinish {
+	case FinishAllowed, FinishForbidden:
+	case FinishPenalty:
+		if r.FinishPenalty <= 0 {
+			return errors.New("finish penalty must be positive")
+		}
+	default:
+		return errors.New("unknown special finish rule")
+	}
+
+	for rank, effect := range r.Effects {
+		if effect == Attack && r.Penalties[rank] <= 0 {
+			return errors.New("attacking ranks must have a positive penalty")
+		}
+	}
+
+	return nil
+}
+
+// EffectOf returns the effect of the given card
+func (r Ruleset) EffectOf(c card.Card) Effect {
+	if c.IsJoker() {
+		return Attack
+	}
+	return r.Effects[c.Rank]
+}
+
+// IsSpecial returns true if the card has any effect
+func (r Ruleset) IsSpecial(c card.Card) bool {
+	return r.EffectOf(c) != None
+}
+
+// IsSkip returns true if the card skips the next player
+func (r Ruleset) IsSkip(c card.Card) bool {
+	return r.EffectOf(c) == Skip
+}
+
+// IsAttack returns true if the card is an attack (wild) card
+func (r Ruleset) IsAttack(c card.Card) bool {
+	return r.EffectOf(c) == Attack
+}
+
+// IsSuitChanger returns true if the card lets the player declare a suit
+func (r Ruleset) IsSuitChanger(c card.Card) bool {
+	return r.EffectOf(c) == SuitChange
+}
+
+// IsTransparent returns true if the card is transparent
+func (r Ruleset) IsTransparent(c card.Card) bool {
+	return r.EffectOf(c) == Transparent
+}
+
+// IsReverse returns true if the card reverses the direction of play
+func (r Ruleset) IsReverse(c card.Card) bool {
+	return r.EffectOf(c) == Reverse
+}
+
+// CanFinishOn returns true if a player may go out by playing the card
+func (r Ruleset) CanFinishOn(c card.Card) bool {
+	return r.SpecialFinish != FinishForbidden || !r.IsSpecial(c)
+}
+
+// PenalizesFinishOn returns true if going out on the card draws the finish penalty
+func (r Ruleset) PenalizesFinishOn(c card.Card) bool {
+	return r.SpecialFinish == FinishPenalty && r.IsSpecial(c)
+}
+
+// DrawPenalty returns the number of cards to draw as penalty for this card
+func (r Ruleset) DrawPenalty(c card.Card) int {
+	if !r.IsAttack(c) {
+		return 0
+	}
+	if c.IsJoker() {
+		return r.JokerPenalty
+	}
+	return r.Penalties[c.Rank]
+}
@@ -0,0 +1,152 @@
+package state
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/djoufson/check-games-engine/card"
+	"github.com/djoufson/check-games-engine/player"
+)
+
+// AnnounceCheck announces "Check!" for the player's last card. A player may
+// announce when down to one card, or on their own turn while holding two
+// cards, before playing the second to last one. A player who reached one card
+// without announcing cannot announce until the challenge window closes.
+func (s *State) AnnounceCheck(playerID string) error {
+	if v := s.checkAnnounceCheck(NewAnnounceCheckMove(playerID)); v != nil {
+		return v
+	}
+
+	s.CheckAnnounced = append(s.CheckAnnounced, playerID)
+	s.emit(Event{Type: EventCheckAnnounced, PlayerID: playerID})
+
+	return nil
+}
+
+// ChallengeCheck challenges a player who reached one card without announcing
+// "Check!". The challenge must come before anyone else makes a move; when it
+// succeeds the target draws the ruleset's check penalty.
+func (s *State) ChallengeCheck(challengerID, targetID string) error {
+	if v := s.checkChallengeCheck(NewChallengeCheckMove(challengerID, targetID)); v != nil {
+		return v
+	}
+
+	target := s.FindPlayerByID(targetID)
+	penalty := s.Rules().CheckPenalty
+	s.CheckExposed = ""
+	s.emit(Event{Type: EventCheckChallenged, PlayerID: targetID, Amount: penalty})
+
+	drawn := s.drawPenalty(target, penalty)
+	s.emit(Event{Type: EventCardsDrawn, PlayerID: targetID, Cards: drawn, Amount: len(drawn)})
+
+	return nil
+}
+
+// HasAnnouncedCheck checks if the player announced "Check!" for their current hand
+func (s *State) HasAnnouncedCheck(playerID string) bool {
+	return slices.Contains(s.CheckAnnounced, playerID)
+}
+
+// checkAnnounceCheck verifies that the player may announce "Check!"
+func (s *State) checkAnnounceCheck(m Move) *RuleViolation {
+	if s.IsGameOver() {
+		return newViolation(ErrGameOver, m, "")
+	}
+
+	p := s.FindPlayerByID(m.PlayerID)
+	if p == nil || !s.IsPlayerActive(m.PlayerID) {
+		return newViolation(ErrPlayerNotFound, m, "")
+	}
+
+	if s.HasAnnouncedCheck(m.PlayerID) {
+		return newViolation(ErrCannotAnnounce, m, "Check was already announced")
+	}
+
+	if s.CheckExposed == m.PlayerID {
+		return newViolation(ErrCannotAnnounce, m, "Check must be announced before reaching one card")
+	}
+
+	switch p.HandSize() {
+	case 1:
+		return nil
+	case 2:
+		if m.PlayerID == s.CurrentPlayerID() && s.CurrentPhase() != PhaseAwaitingSuitChoice {
+			return nil
+		}
+	}
+
+	return newViolation(ErrCannotAnnounce, m, "")
+}
+
+// checkChallengeCheck verifies that the challenger may challenge the target
+func (s *State) checkChallengeCheck(m Move) *RuleViolation {
+	if s.Rules().CheckPenalty <= 0 {
+		return newViolation(ErrChallengesDisabled, m, "")
+	}
+
+	if s.IsGameOver() {
+		return newViolation(ErrGameOver, m, "")
+	}
+
+	if s.FindPlayerByID(m.PlayerID) == nil || !s.IsPlayerActive(m.PlayerID) {
+		return newViolation(ErrPlayerNotFound, m, "")
+	}
+
+	if m.TargetID == "" || m.TargetID == m.PlayerID || m.TargetID != s.CheckExposed {
+		return newViolation(ErrNothingToChallenge, m, fmt.Sprintf("player cannot be challenged: %q", m.TargetID))
+	}
+
+	return nil
+}
+
+// exposeCheck leaves the player open to a challenge if they reached one card
+// without announcing, and forgets the announcement once their hand is empty
+func (s *State) exposeCheck(p *player.Player) {
+	switch {
+	case p.HasEmptyHand():
+		s.forgetCheck(p.ID)
+	case p.HandSize() == 1 && !s.HasAnnouncedCheck(p.ID):
+		s.CheckExposed = p.ID
+	}
+}
+
+// closeCheckWindow ends the chance to challenge once another player makes a move
+func (s *State) closeCheckWindow(playerID string) {
+	if s.CheckExposed != playerID {
+		s.CheckExposed = ""
+	}
+}
+
+// forgetCheck clears the player's announcement, e.g. after they draw more cards
+func (s *State) forgetCheck(playerID string) {
+	s.CheckAnnounced = slices.DeleteFunc(s.CheckAnnounced, func(id string) bool {
+		return id == playerID
+	})
+	if s.CheckExposed == playerID {
+		s.CheckExposed = ""
+	}
+}
+
+// drawPenalty draws up to n cards for the player, reshuffling when needed.
+// When the piles run out, the player draws whatever is left.
+func (s *State) drawPenalty(p *player.Player, n int) []card.Card {
+	drawn := make([]card.Card, 0, max(n, 0))
+	if s.DrawPile == nil {
+		return drawn
+	}
+
+	for i := 0; i < n; i++ {
+		// Handle draw pile exhaustion
+		if s.DrawPile.IsEmpty() && s.ReshuffleDiscardPile() != nil {
+			break
+		}
+
+		c, ok := s.DrawPile.Draw()
+		if !ok {
+			break
+		}
+		p.AddToHand(c)
+		drawn = append(drawn, c)
+	}
+	return drawn
+}
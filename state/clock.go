@@ -0,0 +1,216 @@
+package state
+
+import (
+	"errors"
+	"maps"
+	"time"
+)
+
+// Clock tells the time. Inject a fixed or manually advanced clock to keep
+// timed games deterministic in tests.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the Clock backed by the system time
+type SystemClock struct{}
+
+// Now returns the current system time
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// TimeoutAction decides what happens when a player runs out of time
+type TimeoutAction string
+
+// Timeout actions
+const (
+	TimeoutDraw    TimeoutAction = "DRAW"    // Draw a card, or the penalty of an attack. After a Jack, the Jack's suit is declared.
+	TimeoutPass    TimeoutAction = "PASS"    // Accept an attack if there is one, otherwise pass the turn without drawing. After a Jack, the Jack's suit is declared.
+	TimeoutForfeit TimeoutAction = "FORFEIT" // Leave the game as a loss
+)
+
+// TimeControl configures the turn clocks. A zero duration disables the
+// corresponding limit.
+type TimeControl struct {
+	PerMove   time.Duration `json:"per_move,omitempty"`   // Time allowed for a single turn
+	Bank      time.Duration `json:"bank,omitempty"`       // Total time of each player for the whole game
+	Increment time.Duration `json:"increment,omitempty"`  // Time added to the bank after each completed turn
+	OnTimeout TimeoutAction `json:"on_timeout,omitempty"` // What happens when time runs out (empty means TimeoutDraw)
+}
+
+// Validate checks that the time control is consistent
+func (tc TimeControl) Validate() error {
+	if tc.PerMove < 0 || tc.Bank < 0 || tc.Increment < 0 {
+		return errors.New("time limits cannot be negative")
+	}
+
+	if tc.Increment > 0 && tc.Bank == 0 {
+		return errors.New("an increment requires a bank")
+	}
+
+	switch tc.OnTimeout {
+	case "", TimeoutDraw, TimeoutPass, TimeoutForfeit:
+	default:
+		return errors.New("unknown timeout action")
+	}
+
+	return nil
+}
+
+// Clocks holds the turn clocks of a timed game
+type Clocks struct {
+	Control   TimeControl              `json:"control"`
+	TurnStart time.Time                `json:"turn_start"`      // When the current turn started
+	Banks     map[string]time.Duration `json:"banks,omitempty"` // Time left in each player's bank
+}
+
+// Clone returns a deep copy of the clocks
+func (c *Clocks) Clone() *Clocks {
+	clone := *c
+	clone.Banks = maps.Clone(c.Banks)
+	return &clone
+}
+
+// SetClock sets the time source of the turn clocks. A nil clock uses the system time.
+func (s *State) SetClock(c Clock) {
+	s.clock = c
+}
+
+// now returns the current time of the game's clock
+func (s *State) now() time.Time {
+	if s.clock == nil {
+		return SystemClock{}.Now()
+	}
+	return s.clock.Now()
+}
+
+// startClocks sets up the turn clocks of a new game
+func (s *State) startClocks(tc TimeControl) {
+	s.Clocks = &Clocks{
+		Control:   tc,
+		TurnStart: s.now(),
+	}
+
+	if tc.Bank > 0 {
+		s.Clocks.Banks = make(map[string]time.Duration, len(s.Players))
+		for _, p := range s.Players {
+			s.Clocks.Banks[p.ID] = tc.Bank
+		}
+	}
+}
+
+// TimeLeft returns the time the player has left in their bank. The second
+// return value is false when the game has no bank.
+func (s *State) TimeLeft(playerID string) (time.Duration, bool) {
+	if s.Clocks == nil || s.Clocks.Control.Bank == 0 {
+		return 0, false
+	}
+
+	bank, ok := s.Clocks.Banks[playerID]
+	if !ok {
+		return 0, false
+	}
+	if playerID == s.CurrentPlayerID() {
+		bank -= s.now().Sub(s.Clocks.TurnStart)
+	}
+
+	return max(bank, 0), true
+}
+
+// TurnDeadline returns when the current player runs out of time. The second
+// return value is false when the turn is not timed or the game is over.
+func (s *State) TurnDeadline() (time.Time, bool) {
+	if s.Clocks == nil || s.IsGameOver() {
+		return time.Time{}, false
+	}
+
+	limit, timed := s.Clocks.Control.PerMove, s.Clocks.Control.PerMove > 0
+	if bank, ok := s.Clocks.Banks[s.CurrentPlayerID()]; ok {
+		// The bank cuts the turn short when it holds less than a move's time
+		if !timed || bank < limit {
+			limit = max(bank, 0)
+		}
+		timed = true
+	}
+	if !timed {
+		return time.Time{}, false
+	}
+
+	return s.Clocks.TurnStart.Add(limit), true
+}
+
+// HasTimedOut checks if the current player ran out of time
+func (s *State) HasTimedOut() bool {
+	deadline, ok := s.TurnDeadline()
+	return ok && !s.now().Before(deadline)
+}
+
+// Timeout applies the time control's timeout action to the current player
+// once their time is up
+func (s *State) Timeout(playerID string) error {
+	if v := s.checkTimeout(NewTimeoutMove(playerID)); v != nil {
+		return v
+	}
+
+	s.emit(Event{Type: EventTimedOut, PlayerID: playerID})
+
+	switch s.Clocks.Control.OnTimeout {
+	case TimeoutForfeit:
+		return s.forfeit(playerID)
+	case TimeoutPass:
+		if s.CurrentPhase() == PhaseAwaitingPlay {
+			s.closeCheckWindow(playerID)
+			s.AdvanceTurn()
+			s.endTurn(playerID)
+			return nil
+		}
+	}
+
+	if s.CurrentPhase() == PhaseAwaitingSuitChoice {
+		// The Jack keeps its own suit
+		return s.ChangeSuit(playerID, s.TopCard.Suit)
+	}
+
+	if s.checkDrawCard(NewDrawCardMove(playerID)) != nil {
+		// Nothing left to draw: the turn simply passes
+		s.closeCheckWindow(playerID)
+		s.AdvanceTurn()
+		s.endTurn(playerID)
+		return nil
+	}
+
+	return s.DrawCard(playerID)
+}
+
+// checkTimeout verifies that the player's time is up
+func (s *State) checkTimeout(m Move) *RuleViolation {
+	if v := s.checkPhase(m, PhaseAwaitingPlay, PhaseAwaitingSuitChoice, PhaseAwaitingAttackResponse); v != nil {
+		return v
+	}
+
+	if m.PlayerID != s.CurrentPlayerID() {
+		return newViolation(ErrNotYourTurn, m, "")
+	}
+
+	if !s.HasTimedOut() {
+		return newViolation(ErrTimeNotUp, m, "")
+	}
+
+	return nil
+}
+
+// chargeClock takes the time of the finished turn from the player's bank,
+// adds the increment and starts the clock of the next turn
+func (s *State) chargeClock(playerID string) {
+	if s.Clocks == nil {
+		return
+	}
+
+	now := s.now()
+	if bank, ok := s.Clocks.Banks[playerID]; ok {
+		bank -= now.Sub(s.Clocks.TurnStart)
+		s.Clocks.Banks[playerID] = max(bank, 0) + s.Clocks.Control.Increment
+	}
+	s.Clocks.TurnStart = now
+}
@@ -0,0 +1,122 @@
+package state
+
+import (
+	"errors"
+)
+
+// Sentinel errors returned by state transitions. Rule violations wrap one of
+// these in a *RuleViolation, so they can be matched with errors.Is.
+var (
+	ErrNotEnoughPlayers   = errors.New("at least 2 players are required")
+	ErrNotEnoughCards     = errors.New("not enough cards to deal every hand")
+	ErrInvalidState       = errors.New("invalid state")
+	ErrUnsupportedVersion = errors.New("unsupported state version")
+	ErrPlayerNotFound     = errors.New("player not found")
+	ErrGameOver           = errors.New("game is over")
+	ErrWrongPhase         = errors.New("move not allowed in the current phase")
+	ErrNotYourTurn        = errors.New("not your turn")
+	ErrTurnLocked         = errors.New("turn is locked")
+	ErrTurnNotLocked      = errors.New("turn is not locked")
+	ErrCardNotInHand      = errors.New("card not in hand")
+	ErrInvalidPlay        = errors.New("invalid play")
+	ErrMustDefend         = errors.New("must play a wild card to defend against an attack")
+	ErrInvalidSuit        = errors.New("invalid suit")
+	ErrNoSuitChanger      = errors.New("suit can only be changed after playing a Jack")
+	ErrNoCardsToDraw      = errors.New("no cards left to draw")
+	ErrSpecialFinish      = errors.New("cannot finish on a special card")
+	ErrCannotAnnounce     = errors.New("check can only be announced with one card left")
+	ErrNothingToChallenge = errors.New("player cannot be challenged")
+	ErrChallengesDisabled = errors.New("check challenges are disabled")
+	ErrTimeNotUp          = errors.New("time is not up")
+	ErrMissingCard        = errors.New("play move requires a card")
+	ErrMultiPlayDisabled  = errors.New("playing several cards at once is not allowed")
+	ErrMixedRanks         = errors.New("cards played together must share the same rank")
+	ErrUnknownMove        = errors.New("unknown move type")
+	ErrNotEnoughToShuffle = errors.New("not enough cards to reshuffle")
+	ErrDrawFailed         = errors.New("failed to draw card")
+	ErrRemoveFailed       = errors.New("failed to remove card from hand")
+)
+
+// ViolationCode is a machine-readable identifier for a rule violation
+type ViolationCode string
+
+// Violation codes
+const (
+	CodePlayerNotFound ViolationCode = "PLAYER_NOT_FOUND"
+	CodeGameOver       ViolationCode = "GAME_OVER"
+	CodeWrongPhase     ViolationCode = "WRONG_PHASE"
+	CodeNotYourTurn    ViolationCode = "NOT_YOUR_TURN"
+	CodeTurnLocked     ViolationCode = "TURN_LOCKED"
+	CodeTurnNotLocked  ViolationCode = "TURN_NOT_LOCKED"
+	CodeCardNotInHand  ViolationCode = "CARD_NOT_IN_HAND"
+	CodeInvalidPlay    ViolationCode = "INVALID_PLAY"
+	CodeMustDefend     ViolationCode = "MUST_DEFEND"
+	CodeInvalidSuit    ViolationCode = "INVALID_SUIT"
+	CodeNoSuitChanger  ViolationCode = "NO_SUIT_CHANGER"
+	CodeNoCardsToDraw  ViolationCode = "NO_CARDS_TO_DRAW"
+	CodeMultiPlay      ViolationCode = "MULTI_PLAY_DISABLED"
+	CodeMixedRanks     ViolationCode = "MIXED_RANKS"
+	CodeSpecialFinish  ViolationCode = "SPECIAL_FINISH"
+	CodeCannotAnnounce ViolationCode = "CANNOT_ANNOUNCE"
+	CodeNoChallenge    ViolationCode = "NOTHING_TO_CHALLENGE"
+	CodeNoChallenges   ViolationCode = "CHALLENGES_DISABLED"
+	CodeTimeNotUp      ViolationCode = "TIME_NOT_UP"
+	CodeMalformedMove  ViolationCode = "MALFORMED_MOVE"
+)
+
+// violationCodes maps each rule sentinel to its code
+var violationCodes = map[error]ViolationCode{
+	ErrPlayerNotFound:     CodePlayerNotFound,
+	ErrGameOver:           CodeGameOver,
+	ErrWrongPhase:         CodeWrongPhase,
+	ErrNotYourTurn:        CodeNotYourTurn,
+	ErrTurnLocked:         CodeTurnLocked,
+	ErrTurnNotLocked:      CodeTurnNotLocked,
+	ErrCardNotInHand:      CodeCardNotInHand,
+	ErrInvalidPlay:        CodeInvalidPlay,
+	ErrMustDefend:         CodeMustDefend,
+	ErrInvalidSuit:        CodeInvalidSuit,
+	ErrNoSuitChanger:      CodeNoSuitChanger,
+	ErrNoCardsToDraw:      CodeNoCardsToDraw,
+	ErrMultiPlayDisabled:  CodeMultiPlay,
+	ErrMixedRanks:         CodeMixedRanks,
+	ErrSpecialFinish:      CodeSpecialFinish,
+	ErrCannotAnnounce:     CodeCannotAnnounce,
+	ErrNothingToChallenge: CodeNoChallenge,
+	ErrChallengesDisabled: CodeNoChallenges,
+	ErrTimeNotUp:          CodeTimeNotUp,
+	ErrMissingCard:        CodeMalformedMove,
+	ErrUnknownMove:        CodeMalformedMove,
+}
+
+// RuleViolation describes why a move was rejected
+type RuleViolation struct {
+	Code   ViolationCode `json:"code"`   // Machine-readable code, stable across versions
+	Move   Move          `json:"move"`   // The offending move
+	Reason string        `json:"reason"` // Human-readable explanation
+	Err    error         `json:"-"`      // The matching sentinel error
+}
+
+// Error returns the human-readable reason
+func (v *RuleViolation) Error() string {
+	return v.Reason
+}
+
+// Unwrap returns the sentinel error, so errors.Is works on violations
+func (v *RuleViolation) Unwrap() error {
+	return v.Err
+}
+
+// newViolation creates a violation of the given rule by the move.
+// The reason defaults to the sentinel's message.
+func newViolation(err error, m Move, reason string) *RuleViolation {
+	if reason == "" {
+		reason = err.Error()
+	}
+	return &RuleViolation{
+		Code:   violationCodes[err],
+		Move:   m,
+		Reason: reason,
+		Err:    err,
+	}
+}
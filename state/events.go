@@ -0,0 +1,51 @@
+package state
+
+import (
+	"github.com/djoufson/check-games-engine/card"
+)
+
+// EventType identifies what happened in a state transition
+type EventType string
+
+// Event types
+const (
+	EventCardPlayed       EventType = "CARD_PLAYED"
+	EventCardsDrawn       EventType = "CARDS_DRAWN"
+	EventAttackStarted    EventType = "ATTACK_STARTED"
+	EventAttackEscalated  EventType = "ATTACK_ESCALATED"
+	EventAttackResolved   EventType = "ATTACK_RESOLVED"
+	EventTurnSkipped      EventType = "TURN_SKIPPED"
+	EventDirectionChanged EventType = "DIRECTION_CHANGED"
+	EventSuitDeclared     EventType = "SUIT_DECLARED"
+	EventPileReshuffled   EventType = "PILE_RESHUFFLED"
+	EventFinishPenalized  EventType = "FINISH_PENALIZED"
+	EventCheckAnnounced   EventType = "CHECK_ANNOUNCED"
+	EventCheckChallenged  EventType = "CHECK_CHALLENGED"
+	EventTimedOut         EventType = "TIMED_OUT"
+	EventPlayerForfeited  EventType = "PLAYER_FORFEITED"
+	EventPlayerFinished   EventType = "PLAYER_FINISHED"
+	EventGameOver         EventType = "GAME_OVER"
+)
+
+// Event describes something that happened during a state transition.
+// Only the fields relevant to the event type are set.
+type Event struct {
+	Type      EventType   `json:"type"`
+	PlayerID  string      `json:"player_id,omitempty"` // Player the event is about
+	Cards     []card.Card `json:"cards,omitempty"`     // Cards played or drawn
+	Suit      card.Suit   `json:"suit,omitempty"`      // Declared suit (EventSuitDeclared)
+	Amount    int         `json:"amount,omitempty"`    // Cards drawn, reshuffled, at stake in an attack or as a check penalty
+	Direction *Direction  `json:"direction,omitempty"` // New direction of play (EventDirectionChanged); nil for other events
+}
+
+// emit records an event produced by the current transition
+func (s *State) emit(e Event) {
+	s.events = append(s.events, e)
+}
+
+// DrainEvents returns the events produced since the last call and clears them
+func (s *State) DrainEvents() []Event {
+	events := s.events
+	s.events = nil
+	return events
+}
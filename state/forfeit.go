@@ -0,0 +1,85 @@
+package state
+
+import (
+	"github.com/djoufson/check-games-engine/card"
+)
+
+// Forfeit takes a player who quits or disconnects for good out of the game
+// as a loss. A player may forfeit at any time, even out of turn.
+//
+// Their hand is set aside in ForfeitedHands, out of play, so it can still be
+// scored. Out of turn, the current turn is not affected. On their own turn, the turn passes to the next player: an
+// attack aimed at them ends with them, and a Jack waiting for a suit keeps
+// its own suit.
+func (s *State) Forfeit(playerID string) error {
+	if v := s.checkForfeit(NewForfeitMove(playerID)); v != nil {
+		return v
+	}
+
+	return s.forfeit(playerID)
+}
+
+// checkForfeit verifies that the player may forfeit
+func (s *State) checkForfeit(m Move) *RuleViolation {
+	if s.IsGameOver() {
+		return newViolation(ErrGameOver, m, "")
+	}
+
+	if s.FindPlayerByID(m.PlayerID) == nil || !s.IsPlayerActive(m.PlayerID) {
+		return newViolation(ErrPlayerNotFound, m, "")
+	}
+
+	// Any phase of a game in progress, but not a recorded end or an unknown phase
+	return s.checkPhase(m, PhaseAwaitingPlay, PhaseAwaitingSuitChoice, PhaseAwaitingAttackResponse)
+}
+
+// forfeit takes an active player out of the game as a loss
+func (s *State) forfeit(playerID string) error {
+	p := s.FindPlayerByID(playerID)
+
+	// Set the hand aside, out of play
+	if s.ForfeitedHands == nil {
+		s.ForfeitedHands = make(map[string][]card.Card)
+	}
+	s.ForfeitedHands[playerID] = p.Hand
+	p.Hand = nil
+	s.forgetCheck(playerID)
+
+	onTurn := playerID == s.CurrentPlayerID()
+	if onTurn {
+		switch s.recordedPhase() {
+		case PhaseAwaitingAttackResponse:
+			amount := s.AttackAmount
+			if err := s.enterPhase(PhaseAwaitingPlay); err != nil {
+				return err
+			}
+			s.emit(Event{Type: EventAttackResolved, PlayerID: playerID, Amount: amount})
+		case PhaseAwaitingSuitChoice:
+			// The Jack keeps its own suit
+			if err := s.UnlockTurn(); err != nil {
+				return err
+			}
+		}
+	}
+
+	s.RemovePlayerFromActive(playerID)
+	s.recordForfeit(playerID)
+	s.emit(Event{Type: EventPlayerForfeited, PlayerID: playerID})
+
+	if s.IsGameOver() {
+		// The last player standing finishes ahead of everyone who forfeited
+		if len(s.ActivePlayers) == 1 {
+			s.recordPlacement(s.ActivePlayers[0])
+		}
+		if err := s.enterPhase(PhaseFinished); err != nil {
+			return err
+		}
+		s.emit(Event{Type: EventGameOver, PlayerID: s.GetLoser()})
+	}
+
+	if onTurn {
+		s.endTurn(playerID)
+	}
+
+	return nil
+}
@@ -4,12 +4,13 @@ package state
 import (
 	"encoding/json"
 	"errors"
-	"math/rand"
+	"fmt"
 	"slices"
 
 	"github.com/djoufson/check-games-engine/card"
 	"github.com/djoufson/check-games-engine/deck"
 	"github.com/djoufson/check-games-engine/player"
+	"github.com/djoufson/check-games-engine/rules"
 )
 
 // Direction represents the direction of play
@@ -22,40 +23,110 @@ const (
 	CounterClockwise
 )
 
-// State represents the current state of a game
+// directionNames are the serialized names of the directions
+var directionNames = map[Direction]string{
+	Clockwise:        "CLOCKWISE",
+	CounterClockwise: "COUNTER_CLOCKWISE",
+}
+
+// String returns the name of the direction
+func (d Direction) String() string {
+	if name, ok := directionNames[d]; ok {
+		return name
+	}
+	return fmt.Sprintf("Direction(%d)", int(d))
+}
+
+// MarshalJSON serializes the direction by name
+func (d Direction) MarshalJSON() ([]byte, error) {
+	name, ok := directionNames[d]
+	if !ok {
+		return nil, fmt.Errorf("unknown direction %d", int(d))
+	}
+	return json.Marshal(name)
+}
+
+// UnmarshalJSON deserializes a direction name
+func (d *Direction) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+
+	for direction, n := range directionNames {
+		if n == name {
+			*d = direction
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown direction %q", name)
+}
+
+// State represents the current state of a game. Every action buffers the
+// events it produces until DrainEvents is called: callers driving a State
+// directly must drain them regularly, or the buffer keeps growing. Game.Apply
+// drains them after each move.
 type State struct {
-	Players         []*player.Player `json:"players"`
-	ActivePlayers   []string         `json:"active_players"` // IDs of players still in the game
-	CurrentPlayerId string           `json:"current_player_id"`
-	Direction       Direction        `json:"direction"`
-	DrawPile        *deck.Deck       `json:"draw_pile"`
-	DiscardPile     []card.Card      `json:"discard_pile"`
-	TopCard         card.Card        `json:"top_card"`
-	InAttackChain   bool             `json:"in_attack_chain"`
-	AttackAmount    int              `json:"attack_amount"`
-	LastActiveSuit  card.Suit        `json:"last_active_suit"` // For Jack's suit change effect
-	LockedTurn      bool             `json:"blocked_turn"`     // If the turn is blocked until the suit is changed
+	Players         []*player.Player       `json:"players"`
+	ActivePlayers   []string               `json:"active_players"` // IDs of players still in the game
+	CurrentPlayerId string                 `json:"current_player_id"`
+	Clocks          *Clocks                `json:"clocks,omitempty"` // Turn clocks of a timed game
+	Direction       Direction              `json:"direction"`
+	DrawPile        *deck.Deck             `json:"draw_pile"`
+	DiscardPile     []card.Card            `json:"discard_pile"`
+	TopCard         card.Card              `json:"top_card"`
+	InAttackChain   bool                   `json:"in_attack_chain"`
+	AttackAmount    int                    `json:"attack_amount"`
+	LastActiveSuit  card.Suit              `json:"last_active_suit"`          // For Jack's suit change effect
+	LockedTurn      bool                   `json:"locked_turn"`               // If the turn is locked until the suit is changed
+	Phase           Phase                  `json:"phase,omitempty"`           // Step of the turn the game is waiting for; see CurrentPhase
+	TurnsPlayed     int                    `json:"turns_played"`              // Number of completed turns
+	Placements      []Placement            `json:"placements,omitempty"`      // Players in the order they finished
+	ForfeitedHands  map[string][]card.Card `json:"forfeited_hands,omitempty"` // Hands set aside by players who forfeited, kept for scoring
+	Decks           int                    `json:"decks,omitempty"`           // Number of decks in the shoe; 0 means 1
+	Ruleset         *rules.Ruleset         `json:"ruleset,omitempty"`         // Card semantics in use; nil means rules.Default()
+	RNG             *RNG                   `json:"rng,omitempty"`             // Shuffling RNG, including its stream position
+	CheckAnnounced  []string               `json:"check_announced,omitempty"` // Players who announced "Check!" for their last card
+	CheckExposed    string                 `json:"check_exposed,omitempty"`   // Player who reached one card without announcing and can be challenged
+
+	events []Event // Events produced since the last DrainEvents call
+	clock  Clock   // Time source of the turn clocks; nil means SystemClock
 }
 
 // GameOptions defines configurable options for a new game
 type GameOptions struct {
-	InitialCards  int              // Number of cards dealt to each player at start
+	InitialCards  int              // Number of cards dealt to each player at start (0 uses the ruleset's)
 	RandomSeed    int64            // Seed for RNG (useful for deterministic tests)
 	CustomPlayers []*player.Player // For testing or restarting a game
+	Ruleset       *rules.Ruleset   // House rules to play with (nil uses rules.Default())
+	FirstPlayer   string           // ID of the player who starts (empty means the first ID)
+	Decks         int              // Number of decks in the shoe (0 uses DecksFor the player count)
+	TimeControl   *TimeControl     // Turn clocks to play with (nil for an untimed game)
+	Clock         Clock            // Time source of the turn clocks (nil uses SystemClock)
 }
 
 // DefaultOptions returns the default game options
 func DefaultOptions() GameOptions {
 	return GameOptions{
-		InitialCards: 7,
-		RandomSeed:   0, // Will use time if 0
+		InitialCards: 0, // Uses the ruleset's
+		RandomSeed:   0, // Deterministic like any other seed
 	}
 }
 
+// PlayersPerDeck is the number of players one deck serves by default
+const PlayersPerDeck = 5
+
+// DecksFor returns the default number of decks for a table: one deck for
+// every PlayersPerDeck players
+func DecksFor(playerCount int) int {
+	return max((playerCount+PlayersPerDeck-1)/PlayersPerDeck, 1)
+}
+
 // New creates a new game state with the given player IDs and options
 func New(playerIDs []string, options *GameOptions) (*State, error) {
 	if len(playerIDs) < 2 {
-		return nil, errors.New("at least 2 players are required")
+		return nil, ErrNotEnoughPlayers
 	}
 
 	// Use default options if none provided
@@ -64,14 +135,50 @@ func New(playerIDs []string, options *GameOptions) (*State, error) {
 		opts = *options
 	}
 
+	// Resolve the ruleset and the initial hand size
+	ruleset := rules.Default()
+	if opts.Ruleset != nil {
+		ruleset = opts.Ruleset.Clone()
+	}
+	if err := ruleset.Validate(); err != nil {
+		return nil, err
+	}
+
+	initialCards := opts.InitialCards
+	if initialCards <= 0 {
+		initialCards = ruleset.InitialCards
+	}
+
+	firstPlayer := playerIDs[0]
+	if opts.FirstPlayer != "" {
+		if !slices.Contains(playerIDs, opts.FirstPlayer) {
+			return nil, ErrPlayerNotFound
+		}
+		firstPlayer = opts.FirstPlayer
+	}
+
+	if opts.TimeControl != nil {
+		if err := opts.TimeControl.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	// Create a new seed if none provided
 	seed := opts.RandomSeed
 
 	// Create a new RNG
-	r := rand.New(rand.NewSource(seed))
+	rng := NewRNG(seed)
+	r := rng.Rand()
 
-	// Create and shuffle the deck
-	drawPile := deck.New()
+	// Create and shuffle the shoe, which must hold every hand and the first discard
+	decks := opts.Decks
+	if decks <= 0 {
+		decks = DecksFor(len(playerIDs))
+	}
+	drawPile := deck.NewShoe(decks)
+	if len(playerIDs)*initialCards >= drawPile.Count() {
+		return nil, ErrNotEnoughCards
+	}
 	drawPile.Shuffle(r)
 
 	// Create players
@@ -84,7 +191,7 @@ func New(playerIDs []string, options *GameOptions) (*State, error) {
 	}
 
 	// Deal initial cards
-	for i := 0; i < opts.InitialCards; i++ {
+	for i := 0; i < initialCards; i++ {
 		for _, p := range players {
 			if card, ok := drawPile.Draw(); ok {
 				p.AddToHand(card)
@@ -99,7 +206,7 @@ func New(playerIDs []string, options *GameOptions) (*State, error) {
 	}
 
 	// Ensure the initial discard card isn't a wild card
-	if topCard.IsWildCard() {
+	if ruleset.IsAttack(topCard) {
 		// Put the wild card back in the deck and shuffle again
 		drawPile.AddToBottom(topCard)
 		drawPile.Shuffle(r)
@@ -117,7 +224,7 @@ func New(playerIDs []string, options *GameOptions) (*State, error) {
 	state := &State{
 		Players:         players,
 		ActivePlayers:   activePlayerIDs,
-		CurrentPlayerId: activePlayerIDs[0],
+		CurrentPlayerId: firstPlayer,
 		Direction:       Clockwise,
 		DrawPile:        drawPile,
 		DiscardPile:     discardPile,
@@ -125,12 +232,22 @@ func New(playerIDs []string, options *GameOptions) (*State, error) {
 		InAttackChain:   false,
 		AttackAmount:    0,
 		LastActiveSuit:  topCard.Suit,
+		Phase:           PhaseAwaitingPlay,
+		Decks:           decks,
+		Ruleset:         &ruleset,
+		RNG:             rng,
+		clock:           opts.Clock,
+	}
+
+	if opts.TimeControl != nil {
+		state.startClocks(*opts.TimeControl)
 	}
 
 	return state, nil
 }
 
-// Clone creates a deep copy of the game state
+// Clone creates a deep copy of the game state. Events not yet drained stay
+// with the original.
 func (s *State) Clone() *State {
 	// Clone players
 	players := make([]*player.Player, len(s.Players))
@@ -146,10 +263,13 @@ func (s *State) Clone() *State {
 	copy(activePlayerIDs, s.ActivePlayers)
 
 	// Clone draw pile
-	drawPile := &deck.Deck{
-		Cards: make([]card.Card, len(s.DrawPile.Cards)),
+	var drawPile *deck.Deck
+	if s.DrawPile != nil {
+		drawPile = &deck.Deck{
+			Cards: make([]card.Card, len(s.DrawPile.Cards)),
+		}
+		copy(drawPile.Cards, s.DrawPile.Cards)
 	}
-	copy(drawPile.Cards, s.DrawPile.Cards)
 
 	// Clone discard pile
 	discardPile := make([]card.Card, len(s.DiscardPile))
@@ -167,11 +287,47 @@ func (s *State) Clone() *State {
 		InAttackChain:   s.InAttackChain,
 		AttackAmount:    s.AttackAmount,
 		LastActiveSuit:  s.LastActiveSuit,
+		LockedTurn:      s.LockedTurn,
+		Phase:           s.Phase,
+		TurnsPlayed:     s.TurnsPlayed,
+		Placements:      slices.Clone(s.Placements),
+		Decks:           s.Decks,
+		CheckAnnounced:  slices.Clone(s.CheckAnnounced),
+		CheckExposed:    s.CheckExposed,
+	}
+
+	if s.ForfeitedHands != nil {
+		clone.ForfeitedHands = make(map[string][]card.Card, len(s.ForfeitedHands))
+		for id, hand := range s.ForfeitedHands {
+			clone.ForfeitedHands[id] = slices.Clone(hand)
+		}
 	}
 
+	if s.Ruleset != nil {
+		ruleset := s.Ruleset.Clone()
+		clone.Ruleset = &ruleset
+	}
+
+	if s.RNG != nil {
+		clone.RNG = s.RNG.Clone()
+	}
+
+	if s.Clocks != nil {
+		clone.Clocks = s.Clocks.Clone()
+	}
+	clone.clock = s.clock
+
 	return clone
 }
 
+// Rules returns the ruleset the game is played with
+func (s *State) Rules() rules.Ruleset {
+	if s.Ruleset == nil {
+		return rules.Default()
+	}
+	return *s.Ruleset
+}
+
 // CurrentPlayerID returns the ID of the player whose turn it is
 func (s *State) CurrentPlayerID() string {
 	return s.CurrentPlayerId
@@ -234,16 +390,40 @@ func (s *State) AdvanceTurn() {
 
 // SkipNextPlayer skips the next player's turn (used for Ace)
 func (s *State) SkipNextPlayer() {
+	s.skipPlayers(1)
+}
+
+// skipPlayers skips the turns of the next count players
+func (s *State) skipPlayers(count int) {
 	if len(s.ActivePlayers) <= 2 {
 		// With 2 players, skipping next is equivalent to playing again
+		if next := s.NextPlayer(); next != nil {
+			for i := 0; i < count; i++ {
+				s.emit(Event{Type: EventTurnSkipped, PlayerID: next.ID})
+			}
+		}
 		return
 	}
 
-	// Advance twice
-	s.AdvanceTurn()
+	// Advance once per skipped player, then once more
+	for i := 0; i < count; i++ {
+		s.AdvanceTurn()
+		s.emit(Event{Type: EventTurnSkipped, PlayerID: s.CurrentPlayerId})
+	}
 	s.AdvanceTurn()
 }
 
+// ReverseDirection reverses the direction of play
+func (s *State) ReverseDirection() {
+	if s.Direction == Clockwise {
+		s.Direction = CounterClockwise
+	} else {
+		s.Direction = Clockwise
+	}
+	direction := s.Direction
+	s.emit(Event{Type: EventDirectionChanged, PlayerID: s.CurrentPlayerId, Direction: &direction})
+}
+
 // FindPlayerByID returns the player with the given ID
 func (s *State) FindPlayerByID(id string) *player.Player {
 	for _, p := range s.Players {
@@ -264,95 +444,125 @@ func (s *State) IsPlayerActive(playerID string) bool {
 	return false
 }
 
-// RemovePlayerFromActive removes a player from the active players list
+// RemovePlayerFromActive removes a player from the active players list.
+// When it was their turn, the turn passes to the player who would have
+// played next.
 func (s *State) RemovePlayerFromActive(playerID string) {
-	for i, id := range s.ActivePlayers {
-		if id == playerID {
-			// Remove this player from active players
-			s.ActivePlayers = slices.Delete(s.ActivePlayers, i, i+1)
-
-			// If the removed player was before the current player, adjust the index
-			// if i < s.CurrentPlayerId {
-			// 	s.CurrentPlayerId--
-			// }
-
-			// If the removed player was the current player, don't change the index
-			// (the next player will be at the same index position)
-			// if i == s.CurrentPlayerId && s.CurrentPlayerId >= len(s.ActivePlayers) {
-			// 	s.CurrentPlayerId = 0
-			// }
+	i := slices.Index(s.ActivePlayers, playerID)
+	if i < 0 {
+		return
+	}
 
-			return
-		}
+	if playerID == s.CurrentPlayerId && len(s.ActivePlayers) > 1 {
+		s.CurrentPlayerId = s.ActivePlayers[s.NextPlayerIndex()]
 	}
+	s.ActivePlayers = slices.Delete(s.ActivePlayers, i, i+1)
 }
 
 // PlayCard plays the specified card from the player's hand
 func (s *State) PlayCard(playerID string, c card.Card) error {
-	// Check if it's the player's turn
-	if playerID != s.CurrentPlayerID() {
-		return errors.New("not your turn")
+	if v := s.checkPlayCard(NewPlayCardMove(playerID, c)); v != nil {
+		return v
 	}
 
-	// Verify that the turn is not locked
-	if s.LockedTurn {
-		return errors.New("turn is locked")
-	}
-
-	// Find the player
-	p := s.FindPlayerByID(playerID)
-	if p == nil {
-		return errors.New("player not found")
-	}
+	return s.playCards(playerID, []card.Card{c})
+}
 
-	// Check if the player has the card
-	if !p.HasCard(c) {
-		return errors.New("card not in hand")
+// PlayCards plays several cards of the same rank from the player's hand at once.
+// Only the first card has to match the top card; the effects of all the cards add up.
+func (s *State) PlayCards(playerID string, cards []card.Card) error {
+	if v := s.checkPlayCards(NewPlayCardsMove(playerID, cards)); v != nil {
+		return v
 	}
 
-	// Check if the play is valid
-	if !player.CanPlayCardOn(c, s.TopCard, s.InAttackChain) {
-		return errors.New("invalid play")
-	}
+	return s.playCards(playerID, cards)
+}
 
-	// If in an attack chain, only wild cards can be played on wild cards
-	if s.InAttackChain && !c.IsWildCard() {
-		return errors.New("must play a wild card to defend against an attack")
-	}
+// playCards moves already validated cards to the discard pile and applies their effects
+func (s *State) playCards(playerID string, cards []card.Card) error {
+	p := s.FindPlayerByID(playerID)
+	s.closeCheckWindow(playerID)
 
-	// Remove the card from the player's hand
-	_, ok := p.RemoveFromHand(c)
+	// Remove the cards from the player's hand, keeping the played instances
+	cards, ok := p.RemoveCardsFromHand(cards)
 	if !ok {
-		return errors.New("failed to remove card from hand")
+		return ErrRemoveFailed
 	}
 
-	// Add the card to the discard pile
-	s.DiscardPile = append(s.DiscardPile, c)
+	// Add the cards to the discard pile, the last one ending on top
+	s.DiscardPile = append(s.DiscardPile, cards...)
+	c := cards[len(cards)-1]
 	s.TopCard = c
+	s.emit(Event{Type: EventCardPlayed, PlayerID: playerID, Cards: slices.Clone(cards)})
 
-	// Update the last active suit (for Jack's suit change)
-	if !c.IsJoker() {
+	rs := s.Rules()
+
+	// Update the last active suit, which also clears any suit declared with a Jack.
+	// Jokers and transparent cards keep the suit of the card underneath them.
+	if !c.IsJoker() && !rs.IsTransparent(c) {
 		s.LastActiveSuit = c.Suit
 	}
 
 	// Handle wild cards
-	if c.IsWildCard() {
-		if s.InAttackChain {
-			// Add to the attack amount
-			s.AttackAmount += c.GetDrawPenalty()
-		} else {
-			// Start a new attack chain
-			s.InAttackChain = true
-			s.AttackAmount = c.GetDrawPenalty()
+	if rs.IsAttack(c) {
+		for _, attack := range cards {
+			if s.InAttackChain {
+				// Add to the attack amount
+				s.AttackAmount += rs.DrawPenalty(attack)
+				s.emit(Event{Type: EventAttackEscalated, PlayerID: playerID, Amount: s.AttackAmount})
+			} else {
+				// Start a new attack chain
+				if err := s.enterPhase(PhaseAwaitingAttackResponse); err != nil {
+					return err
+				}
+				s.AttackAmount = rs.DrawPenalty(attack)
+				s.emit(Event{Type: EventAttackStarted, PlayerID: playerID, Amount: s.AttackAmount})
+			}
 		}
 	}
 
 	// Process special card effects
-	s.ProcessCardEffect(c)
+	if err := s.processCardEffects(c, len(cards)); err != nil {
+		return err
+	}
+
+	// Going out on a special card may draw a penalty instead
+	if p.HasEmptyHand() && rs.PenalizesFinishOn(c) {
+		drawn := s.drawPenalty(p, rs.FinishPenalty)
+		s.forgetCheck(playerID)
+		s.emit(Event{Type: EventFinishPenalized, PlayerID: playerID, Amount: rs.FinishPenalty})
+		s.emit(Event{Type: EventCardsDrawn, PlayerID: playerID, Cards: drawn, Amount: len(drawn)})
+	}
+
+	// A player down to one card must have announced "Check!"
+	s.exposeCheck(p)
 
 	// Check if the player has emptied their hand
 	if p.HasEmptyHand() {
+		if s.LockedTurn {
+			// Nobody is left to declare a suit for, so the Jack ends the turn
+			if err := s.UnlockTurn(); err != nil {
+				return err
+			}
+			s.AdvanceTurn()
+		}
+
 		s.RemovePlayerFromActive(playerID)
+		s.recordPlacement(playerID)
+		s.emit(Event{Type: EventPlayerFinished, PlayerID: playerID})
+
+		if s.IsGameOver() {
+			if err := s.enterPhase(PhaseFinished); err != nil {
+				return err
+			}
+			s.recordPlacement(s.ActivePlayers[0])
+			s.emit(Event{Type: EventGameOver, PlayerID: s.GetLoser()})
+		}
+	}
+
+	// The turn goes on until a suit is declared after a Jack
+	if s.CurrentPhase() != PhaseAwaitingSuitChoice {
+		s.endTurn(playerID)
 	}
 
 	return nil
@@ -360,16 +570,12 @@ func (s *State) PlayCard(playerID string, c card.Card) error {
 
 // DrawCard makes the current player draw a card
 func (s *State) DrawCard(playerID string) error {
-	// Check if it's the player's turn
-	if playerID != s.CurrentPlayerID() {
-		return errors.New("not your turn")
+	if v := s.checkDrawCard(NewDrawCardMove(playerID)); v != nil {
+		return v
 	}
 
-	// Find the player
 	p := s.FindPlayerByID(playerID)
-	if p == nil {
-		return errors.New("player not found")
-	}
+	s.closeCheckWindow(playerID)
 
 	// Handle draw pile exhaustion
 	if s.DrawPile.IsEmpty() {
@@ -382,127 +588,178 @@ func (s *State) DrawCard(playerID string) error {
 	// Draw a card for the player
 	c, ok := s.DrawPile.Draw()
 	if !ok {
-		return errors.New("failed to draw card")
+		return ErrDrawFailed
 	}
 
 	// Add the card to the player's hand
 	p.AddToHand(c)
+	drawn := []card.Card{c}
 
-	// If in an attack chain, the player must draw the attack amount and end the chain
+	// If in an attack chain, the player must draw the attack amount and end the chain.
+	// When the piles run out, the player draws whatever is left.
+	attackAmount := s.AttackAmount
 	if s.InAttackChain {
 		// Draw the remaining attack amount - 1 (we already drew one)
-		for i := 1; i < s.AttackAmount; i++ {
-			// Handle draw pile exhaustion again if needed
-			if s.DrawPile.IsEmpty() {
-				err := s.ReshuffleDiscardPile()
-				if err != nil {
-					return err
-				}
-			}
-
-			c, ok := s.DrawPile.Draw()
-			if !ok {
-				return errors.New("failed to draw attack penalty cards")
-			}
-			p.AddToHand(c)
-		}
+		drawn = append(drawn, s.drawPenalty(p, s.AttackAmount-1)...)
+	}
+	s.forgetCheck(playerID)
+	s.emit(Event{Type: EventCardsDrawn, PlayerID: playerID, Cards: drawn, Amount: len(drawn)})
 
+	if s.InAttackChain {
 		// End the attack chain
-		s.InAttackChain = false
-		s.AttackAmount = 0
+		if err := s.enterPhase(PhaseAwaitingPlay); err != nil {
+			return err
+		}
+		s.emit(Event{Type: EventAttackResolved, PlayerID: playerID, Amount: attackAmount})
 	}
 
 	// Advance to the next player's turn
 	s.AdvanceTurn()
+	s.endTurn(playerID)
 
 	return nil
 }
 
 // ProcessCardEffect processes the effect of the played card
-func (s *State) ProcessCardEffect(c card.Card) {
-	if c.IsSkip() {
-		// Ace skips the next player
-		s.SkipNextPlayer()
-	} else if c.IsWildCard() && s.InAttackChain {
+func (s *State) ProcessCardEffect(c card.Card) error {
+	return s.processCardEffects(c, 1)
+}
+
+// processCardEffects processes the combined effect of count cards of the same rank
+func (s *State) processCardEffects(c card.Card, count int) error {
+	rs := s.Rules()
+	if rs.IsSkip(c) {
+		// Each Ace skips one more player
+		s.skipPlayers(count)
+	} else if rs.IsAttack(c) && s.InAttackChain {
 		// In an attack chain, wild cards DO advance the turn
 		// The next player must defend or draw
 		s.AdvanceTurn()
-	} else if c.IsSuitChanger() {
+	} else if rs.IsReverse(c) {
+		// Reversal changes the direction of play, an even number of them cancel out
+		for i := 0; i < count; i++ {
+			s.ReverseDirection()
+		}
+		if count%2 == 1 && len(s.ActivePlayers) <= 2 {
+			// With 2 players, reversing acts like a skip: the player plays again
+			return nil
+		}
+		s.AdvanceTurn()
+	} else if rs.IsSuitChanger(c) {
 		// Jack changes the suit
 		// So the turn is locked until the suit is changed
-		s.LockTurn()
+		return s.LockTurn()
 	} else if !s.InAttackChain {
 		// In normal play, advance to the next player if not in an attack chain
 		// and the card isn't a special card that changes turn order
 		s.AdvanceTurn()
 	}
+	return nil
 }
 
-// LockTurn locks the turn until the suit is changed
-func (s *State) LockTurn() {
-	s.LockedTurn = true
+// LockTurn locks the turn until the suit is changed. It returns
+// ErrInvalidState if the current phase cannot lead to a suit choice.
+func (s *State) LockTurn() error {
+	return s.enterPhase(PhaseAwaitingSuitChoice)
 }
 
-// UnlockTurn unlocks the turn
-func (s *State) UnlockTurn() {
-	s.LockedTurn = false
+// UnlockTurn unlocks the turn once the suit is changed. It returns
+// ErrInvalidState if the current phase cannot go back to normal play.
+func (s *State) UnlockTurn() error {
+	return s.enterPhase(PhaseAwaitingPlay)
 }
 
 // ReshuffleDiscardPile reshuffles the discard pile (except top card) into the draw pile
 func (s *State) ReshuffleDiscardPile() error {
-	if len(s.DiscardPile) <= 1 {
-		return errors.New("not enough cards to reshuffle")
+	// Keep the top card, along with the card seen through any stacked transparent cards
+	keep := s.reshuffleIndex()
+	if keep == 0 {
+		return ErrNotEnoughToShuffle
 	}
 
-	// Keep the top card
-	topCard := s.DiscardPile[len(s.DiscardPile)-1]
-
 	// Add all other discard cards to draw pile
-	cardsToShuffle := s.DiscardPile[:len(s.DiscardPile)-1]
-	s.DrawPile.AddManyToBottom(cardsToShuffle)
+	s.DrawPile.AddManyToBottom(slices.Clone(s.DiscardPile[:keep]))
+	s.emit(Event{Type: EventPileReshuffled, Amount: keep})
 
-	// Reset the discard pile with just the top card
-	s.DiscardPile = []card.Card{topCard}
+	// Reset the discard pile with just the kept cards
+	s.DiscardPile = slices.Clone(s.DiscardPile[keep:])
 
-	// Shuffle the draw pile
-	seed := rand.Int63()
-	r := rand.New(rand.NewSource(seed))
-	s.DrawPile.Shuffle(r)
+	// Shuffle the draw pile with the game's own RNG so the game stays reproducible
+	if s.RNG == nil {
+		s.RNG = NewRNG(0)
+	}
+	s.DrawPile.Shuffle(s.RNG.Rand())
 
 	return nil
 }
 
-// ChangeSuit changes the active suit (for Jack effect)
-func (s *State) ChangeSuit(playerID string, newSuit card.Suit) error {
-	// Verify it's the player's turn
-	if playerID != s.CurrentPlayerID() {
-		return errors.New("not your turn")
+// reshuffleIndex returns the index of the first discard that must stay on the
+// pile when reshuffling. Everything below it can go back into the draw pile.
+func (s *State) reshuffleIndex() int {
+	if len(s.DiscardPile) <= 1 {
+		return 0
 	}
 
-	// Verify that the turn is locked
-	if !s.LockedTurn {
-		return errors.New("turn is not locked")
+	rs := s.Rules()
+	keep := len(s.DiscardPile) - 1
+	for keep > 0 && rs.IsTransparent(s.DiscardPile[keep]) {
+		keep--
 	}
-
-	if !isValidSuit(newSuit) {
-		return errors.New("invalid suit")
+	if rs.IsTransparent(s.DiscardPile[keep]) {
+		// Only transparent cards: there is nothing to see through
+		keep = len(s.DiscardPile) - 1
 	}
+	return keep
+}
 
-	// Verify that the last card played was a Jack
-	if !s.TopCard.IsSuitChanger() {
-		return errors.New("suit can only be changed after playing a Jack")
+// ChangeSuit changes the active suit (for Jack effect)
+func (s *State) ChangeSuit(playerID string, newSuit card.Suit) error {
+	if v := s.checkChangeSuit(NewChangeSuitMove(playerID, newSuit)); v != nil {
+		return v
 	}
 
+	s.closeCheckWindow(playerID)
+
 	// Change the suit
 	s.LastActiveSuit = newSuit
-	s.UnlockTurn()
+	s.emit(Event{Type: EventSuitDeclared, PlayerID: playerID, Suit: newSuit})
+	if err := s.UnlockTurn(); err != nil {
+		return err
+	}
 	s.AdvanceTurn()
+	s.endTurn(playerID)
 
 	return nil
 }
 
+// ActiveSuit returns the suit the next card must follow. After a Jack this is
+// the declared suit, otherwise it is the suit of the effective top card.
+func (s *State) ActiveSuit() card.Suit {
+	topCard := s.EffectiveTopCard()
+	if s.Rules().IsSuitChanger(topCard) && s.LastActiveSuit != "" {
+		return s.LastActiveSuit
+	}
+	return topCard.Suit
+}
+
+// EffectiveTopCard returns the card the next play has to match. Transparent
+// cards (2s) are looked through, so this is the topmost discard that is not
+// a 2, or the top card itself if the pile holds nothing else.
+func (s *State) EffectiveTopCard() card.Card {
+	rs := s.Rules()
+	for i := len(s.DiscardPile) - 1; i >= 0; i-- {
+		if !rs.IsTransparent(s.DiscardPile[i]) {
+			return s.DiscardPile[i]
+		}
+	}
+	return s.TopCard
+}
+
+// declarableSuits lists the suits that can be declared with a Jack
+var declarableSuits = []card.Suit{card.Spades, card.Hearts, card.Diamonds, card.Clubs}
+
 func isValidSuit(newSuit card.Suit) bool {
-	return newSuit == card.Hearts || newSuit == card.Diamonds || newSuit == card.Spades || newSuit == card.Clubs
+	return slices.Contains(declarableSuits, newSuit)
 }
 
 // IsGameOver checks if the game is over
@@ -510,13 +767,22 @@ func (s *State) IsGameOver() bool {
 	return len(s.ActivePlayers) <= 1
 }
 
-// GetWinner returns the IDs of players who have won (emptied their hands)
+// GetWinner returns the IDs of players who have won, in the order they
+// finished: everyone placed ahead of the loser who did not forfeit
 func (s *State) GetWinner() []string {
 	winners := make([]string, 0)
 
-	// Anyone who emptied their hand is a winner
+	// Players recorded in the standings come first, in finishing order
+	loser := s.GetLoser()
+	for _, placement := range s.Standings() {
+		if placement.PlayerID != loser && !placement.Forfeited {
+			winners = append(winners, placement.PlayerID)
+		}
+	}
+
+	// Anyone else who emptied their hand is a winner too
 	for _, p := range s.Players {
-		if p.HasEmptyHand() {
+		if p.HasEmptyHand() && !slices.Contains(winners, p.ID) && !s.hasForfeited(p.ID) {
 			winners = append(winners, p.ID)
 		}
 	}
@@ -524,23 +790,16 @@ func (s *State) GetWinner() []string {
 	return winners
 }
 
-// GetLoser returns the ID of the last player left with cards
+// GetLoser returns the ID of the player in last place once the game is over:
+// the last player left with cards, unless someone forfeited
 func (s *State) GetLoser() string {
 	if len(s.ActivePlayers) != 1 {
 		return ""
 	}
 
-	return s.ActivePlayers[0]
-}
-
-// ToJSON serializes the game state to JSON
-func (s *State) ToJSON() ([]byte, error) {
-	return json.Marshal(s)
-}
+	if standings := s.Standings(); len(standings) == len(s.Players) {
+		return standings[len(standings)-1].PlayerID
+	}
 
-// FromJSON deserializes the game state from JSON
-func FromJSON(data []byte) (*State, error) {
-	state := &State{}
-	err := json.Unmarshal(data, state)
-	return state, err
+	return s.ActivePlayers[0]
 }
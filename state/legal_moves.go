@@ -0,0 +1,224 @@
+package state
+
+import (
+	"fmt"
+
+	"github.com/djoufson/check-games-engine/card"
+	"github.com/djoufson/check-games-engine/player"
+)
+
+// CheckMove reports whether the move can be applied to the current state.
+// It runs the same checks as the corresponding action without modifying the state.
+// A rejected move is reported as a *RuleViolation.
+func (s *State) CheckMove(m Move) error {
+	if v := s.checkMove(m); v != nil {
+		return v
+	}
+	return nil
+}
+
+// checkMove dispatches the move to the check of its action
+func (s *State) checkMove(m Move) *RuleViolation {
+	switch m.Type {
+	case MovePlayCard:
+		if m.Card == nil {
+			return newViolation(ErrMissingCard, m, "")
+		}
+		return s.checkPlayCard(m)
+	case MovePlayCards:
+		return s.checkPlayCards(m)
+	case MoveDrawCard:
+		return s.checkDrawCard(m)
+	case MoveChangeSuit:
+		return s.checkChangeSuit(m)
+	case MoveAnnounceCheck:
+		return s.checkAnnounceCheck(m)
+	case MoveChallengeCheck:
+		return s.checkChallengeCheck(m)
+	case MoveTimeout:
+		return s.checkTimeout(m)
+	case MoveForfeit:
+		return s.checkForfeit(m)
+	default:
+		return newViolation(ErrUnknownMove, m, fmt.Sprintf("unknown move type: %q", m.Type))
+	}
+}
+
+// LegalMoves returns every move the player may make right now. Out of turn,
+// only announcing or challenging "Check!" can be legal. When several cards may be played at
+// once, each playable card is also offered together with every other card of
+// its rank in the hand. Timeouts and forfeits are not game moves and are
+// never listed.
+func (s *State) LegalMoves(playerID string) []Move {
+	moves := make([]Move, 0)
+
+	p := s.FindPlayerByID(playerID)
+	if p == nil {
+		return moves
+	}
+
+	candidates := make([]Move, 0, len(p.Hand)+1+len(declarableSuits))
+	for _, c := range p.Hand {
+		candidates = append(candidates, NewPlayCardMove(playerID, c))
+	}
+	if s.Rules().MultiPlay {
+		for i, c := range p.Hand {
+			cards := []card.Card{c}
+			for j, other := range p.Hand {
+				if j != i && other.Rank == c.Rank {
+					cards = append(cards, other)
+				}
+			}
+			if len(cards) > 1 {
+				candidates = append(candidates, NewPlayCardsMove(playerID, cards))
+			}
+		}
+	}
+	candidates = append(candidates, NewDrawCardMove(playerID))
+	for _, suit := range declarableSuits {
+		candidates = append(candidates, NewChangeSuitMove(playerID, suit))
+	}
+	candidates = append(candidates, NewAnnounceCheckMove(playerID))
+	if s.CheckExposed != "" {
+		candidates = append(candidates, NewChallengeCheckMove(playerID, s.CheckExposed))
+	}
+
+	for _, m := range candidates {
+		if s.CheckMove(m) == nil {
+			moves = append(moves, m)
+		}
+	}
+
+	return moves
+}
+
+// checkPlayCard verifies that the player may play the card of the move
+func (s *State) checkPlayCard(m Move) *RuleViolation {
+	c := *m.Card
+
+	// Cards are played in normal play or in response to an attack
+	if v := s.checkPhase(m, PhaseAwaitingPlay, PhaseAwaitingAttackResponse); v != nil {
+		return v
+	}
+
+	// Check if it's the player's turn
+	if m.PlayerID != s.CurrentPlayerID() {
+		return newViolation(ErrNotYourTurn, m, "")
+	}
+
+	// Find the player
+	p := s.FindPlayerByID(m.PlayerID)
+	if p == nil {
+		return newViolation(ErrPlayerNotFound, m, "")
+	}
+
+	// Check if the player has the card
+	if !p.HasCard(c) {
+		return newViolation(ErrCardNotInHand, m, fmt.Sprintf("card not in hand: %s", c))
+	}
+
+	// Check if the play is valid
+	rs := s.Rules()
+	if !player.CanPlayCardUnder(rs, c, s.EffectiveTopCard(), s.ActiveSuit(), s.InAttackChain) {
+		return newViolation(ErrInvalidPlay, m, fmt.Sprintf("invalid play: %s cannot be played on %s", c, s.EffectiveTopCard()))
+	}
+
+	// If in an attack chain, only wild cards can be played on wild cards
+	if s.InAttackChain && !rs.IsAttack(c) {
+		return newViolation(ErrMustDefend, m, "")
+	}
+
+	// The ruleset may forbid going out on a special card
+	if p.HandSize() == 1 && !rs.CanFinishOn(c) {
+		return newViolation(ErrSpecialFinish, m, fmt.Sprintf("cannot finish on a special card: %s", c))
+	}
+
+	return nil
+}
+
+// checkPlayCards verifies that the player may play the cards of the move together.
+// Only the first card is checked against the top card.
+func (s *State) checkPlayCards(m Move) *RuleViolation {
+	if len(m.Cards) == 0 {
+		return newViolation(ErrMissingCard, m, "")
+	}
+
+	if len(m.Cards) > 1 && !s.Rules().MultiPlay {
+		return newViolation(ErrMultiPlayDisabled, m, "")
+	}
+
+	// Check the first card like a single play
+	if v := s.checkPlayCard(NewPlayCardMove(m.PlayerID, m.Cards[0])); v != nil {
+		v.Move = m
+		return v
+	}
+
+	for _, c := range m.Cards[1:] {
+		if c.Rank != m.Cards[0].Rank {
+			return newViolation(ErrMixedRanks, m, fmt.Sprintf("cards played together must share the same rank: %s and %s", m.Cards[0], c))
+		}
+	}
+
+	// Check that the player holds every card
+	p := s.FindPlayerByID(m.PlayerID)
+	if !p.HasCards(m.Cards) {
+		return newViolation(ErrCardNotInHand, m, "not all cards are in hand")
+	}
+
+	// The ruleset may forbid going out on a special card
+	last := m.Cards[len(m.Cards)-1]
+	if p.HandSize() == len(m.Cards) && !s.Rules().CanFinishOn(last) {
+		return newViolation(ErrSpecialFinish, m, fmt.Sprintf("cannot finish on a special card: %s", last))
+	}
+
+	return nil
+}
+
+// checkDrawCard verifies that the player may draw (or take the attack penalty)
+func (s *State) checkDrawCard(m Move) *RuleViolation {
+	// A locked turn must be resolved by declaring a suit
+	if v := s.checkPhase(m, PhaseAwaitingPlay, PhaseAwaitingAttackResponse); v != nil {
+		return v
+	}
+
+	// Check if it's the player's turn
+	if m.PlayerID != s.CurrentPlayerID() {
+		return newViolation(ErrNotYourTurn, m, "")
+	}
+
+	// Find the player
+	if s.FindPlayerByID(m.PlayerID) == nil {
+		return newViolation(ErrPlayerNotFound, m, "")
+	}
+
+	// There must be at least one card left to draw
+	if s.DrawPile == nil || (s.DrawPile.IsEmpty() && s.reshuffleIndex() == 0) {
+		return newViolation(ErrNoCardsToDraw, m, "")
+	}
+
+	return nil
+}
+
+// checkChangeSuit verifies that the player may declare the suit of the move
+func (s *State) checkChangeSuit(m Move) *RuleViolation {
+	// Verify that the turn is locked
+	if v := s.checkPhase(m, PhaseAwaitingSuitChoice); v != nil {
+		return v
+	}
+
+	// Verify it's the player's turn
+	if m.PlayerID != s.CurrentPlayerID() {
+		return newViolation(ErrNotYourTurn, m, "")
+	}
+
+	if !isValidSuit(m.Suit) {
+		return newViolation(ErrInvalidSuit, m, fmt.Sprintf("invalid suit: %q", m.Suit))
+	}
+
+	// Verify that the last card played was a Jack
+	if !s.Rules().IsSuitChanger(s.TopCard) {
+		return newViolation(ErrNoSuitChanger, m, "")
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package state
+
+import (
+	"slices"
+
+	"github.com/djoufson/check-games-engine/card"
+)
+
+// MoveType identifies the kind of action carried by a Move
+type MoveType string
+
+// Move types
+const (
+	MovePlayCard       MoveType = "PLAY_CARD"
+	MovePlayCards      MoveType = "PLAY_CARDS"
+	MoveDrawCard       MoveType = "DRAW_CARD"
+	MoveChangeSuit     MoveType = "CHANGE_SUIT"
+	MoveAnnounceCheck  MoveType = "ANNOUNCE_CHECK"
+	MoveChallengeCheck MoveType = "CHALLENGE_CHECK"
+	MoveTimeout        MoveType = "TIMEOUT"
+	MoveForfeit        MoveType = "FORFEIT"
+)
+
+// Move represents a single player action that can be applied to a State.
+// Only the fields relevant to the move type are set, so a Move can be sent
+// over the network, stored in a replay or produced by a bot in one format.
+type Move struct {
+	Type     MoveType    `json:"type"`
+	PlayerID string      `json:"player_id"`
+	Card     *card.Card  `json:"card,omitempty"`      // Card to play (MovePlayCard)
+	Cards    []card.Card `json:"cards,omitempty"`     // Cards of the same rank to play, first one on the pile first (MovePlayCards)
+	Suit     card.Suit   `json:"suit,omitempty"`      // Suit to declare (MoveChangeSuit)
+	TargetID string      `json:"target_id,omitempty"` // Player being challenged (MoveChallengeCheck)
+}
+
+// NewPlayCardMove creates a move that plays the given card
+func NewPlayCardMove(playerID string, c card.Card) Move {
+	return Move{
+		Type:     MovePlayCard,
+		PlayerID: playerID,
+		Card:     &c,
+	}
+}
+
+// NewPlayCardsMove creates a move that plays several cards of the same rank at once
+func NewPlayCardsMove(playerID string, cards []card.Card) Move {
+	return Move{
+		Type:     MovePlayCards,
+		PlayerID: playerID,
+		Cards:    slices.Clone(cards),
+	}
+}
+
+// NewDrawCardMove creates a move that draws a card (or the attack penalty)
+func NewDrawCardMove(playerID string) Move {
+	return Move{
+		Type:     MoveDrawCard,
+		PlayerID: playerID,
+	}
+}
+
+// NewChangeSuitMove creates a move that declares a new suit after a Jack
+func NewChangeSuitMove(playerID string, suit card.Suit) Move {
+	return Move{
+		Type:     MoveChangeSuit,
+		PlayerID: playerID,
+		Suit:     suit,
+	}
+}
+
+// NewAnnounceCheckMove creates a move that announces "Check!" for the last card
+func NewAnnounceCheckMove(playerID string) Move {
+	return Move{
+		Type:     MoveAnnounceCheck,
+		PlayerID: playerID,
+	}
+}
+
+// NewChallengeCheckMove creates a move that challenges a player who did not announce "Check!"
+func NewChallengeCheckMove(playerID, targetID string) Move {
+	return Move{
+		Type:     MoveChallengeCheck,
+		PlayerID: playerID,
+		TargetID: targetID,
+	}
+}
+
+// NewTimeoutMove creates a move that applies the timeout action to a player whose time is up
+func NewTimeoutMove(playerID string) Move {
+	return Move{
+		Type:     MoveTimeout,
+		PlayerID: playerID,
+	}
+}
+
+// NewForfeitMove creates a move that takes the player out of the game as a loss
+func NewForfeitMove(playerID string) Move {
+	return Move{
+		Type:     MoveForfeit,
+		PlayerID: playerID,
+	}
+}
+
+// Apply applies the given move to the state
+func (s *State) Apply(m Move) error {
+	switch m.Type {
+	case MovePlayCard:
+		if m.Card == nil {
+			return newViolation(ErrMissingCard, m, "")
+		}
+		return s.PlayCard(m.PlayerID, *m.Card)
+	case MovePlayCards:
+		return s.PlayCards(m.PlayerID, m.Cards)
+	case MoveDrawCard:
+		return s.DrawCard(m.PlayerID)
+	case MoveChangeSuit:
+		return s.ChangeSuit(m.PlayerID, m.Suit)
+	case MoveAnnounceCheck:
+		return s.AnnounceCheck(m.PlayerID)
+	case MoveChallengeCheck:
+		return s.ChallengeCheck(m.PlayerID, m.TargetID)
+	case MoveTimeout:
+		return s.Timeout(m.PlayerID)
+	case MoveForfeit:
+		return s.Forfeit(m.PlayerID)
+	default:
+		return s.CheckMove(m)
+	}
+}
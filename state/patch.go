@@ -0,0 +1,305 @@
+package state
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/djoufson/check-games-engine/card"
+	"github.com/djoufson/check-games-engine/deck"
+	"github.com/djoufson/check-games-engine/player"
+)
+
+// ErrPatchMismatch is returned when a patch does not fit the state it is applied to
+var ErrPatchMismatch = errors.New("patch does not apply to this state")
+
+// Patch describes the changes between two states, so a client holding the
+// old state can catch up without receiving a full snapshot
+type Patch struct {
+	Hands       []HandChange               `json:"hands,omitempty"`
+	DrawPile    *PileChange                `json:"draw_pile,omitempty"`
+	DiscardPile *PileChange                `json:"discard_pile,omitempty"`
+	Fields      map[string]json.RawMessage `json:"fields,omitempty"` // Other changed fields by JSON name; null clears a field
+}
+
+// HandChange describes how a player's hand changed. Removed cards are taken
+// out first, then added cards go to the end of the hand. Hands that were
+// reordered are sent whole instead.
+type HandChange struct {
+	PlayerID string      `json:"player_id"`
+	Removed  []card.Card `json:"removed,omitempty"`
+	Added    []card.Card `json:"added,omitempty"`
+	Hand     []card.Card `json:"hand,omitempty"` // Full new hand; replaces Removed and Added when set
+}
+
+// PileChange describes how a pile changed: Drop cards are taken from its
+// front, then the Push cards are added at its end. The front is the top of
+// the draw pile and the bottom of the discard pile.
+type PileChange struct {
+	Drop int         `json:"drop,omitempty"`
+	Push []card.Card `json:"push,omitempty"`
+}
+
+// patchedFields are the fields carried by the dedicated parts of a Patch
+var patchedFields = []string{"players", "draw_pile", "discard_pile"}
+
+// IsEmpty checks if the patch changes nothing
+func (p *Patch) IsEmpty() bool {
+	return len(p.Hands) == 0 && p.DrawPile == nil && p.DiscardPile == nil && len(p.Fields) == 0
+}
+
+// Diff returns the patch that turns one state into another
+func Diff(from, to *State) (*Patch, error) {
+	patch := &Patch{}
+
+	fromFields, err := from.fields()
+	if err != nil {
+		return nil, err
+	}
+	toFields, err := to.fields()
+	if err != nil {
+		return nil, err
+	}
+
+	samePlayers := slices.EqualFunc(from.Players, to.Players, func(a, b *player.Player) bool {
+		return a.ID == b.ID
+	})
+	if samePlayers {
+		for i, p := range to.Players {
+			if change, ok := diffHand(from.Players[i].Hand, p.Hand); ok {
+				change.PlayerID = p.ID
+				patch.Hands = append(patch.Hands, change)
+			}
+		}
+	} else {
+		// Seats changed, so the players are sent whole
+		players, err := json.Marshal(to.Players)
+		if err != nil {
+			return nil, err
+		}
+		toFields["players"] = players
+	}
+
+	patch.DrawPile = diffPile(from.drawPileCards(), to.drawPileCards())
+	patch.DiscardPile = diffPile(from.DiscardPile, to.DiscardPile)
+
+	for name, value := range toFields {
+		if !bytes.Equal(fromFields[name], value) {
+			if patch.Fields == nil {
+				patch.Fields = make(map[string]json.RawMessage)
+			}
+			patch.Fields[name] = value
+		}
+	}
+	for name := range fromFields {
+		if _, ok := toFields[name]; !ok {
+			if patch.Fields == nil {
+				patch.Fields = make(map[string]json.RawMessage)
+			}
+			patch.Fields[name] = json.RawMessage("null")
+		}
+	}
+
+	return patch, nil
+}
+
+// ApplyPatch applies a patch produced by Diff. The state is left unchanged
+// when the patch does not fit it.
+func (s *State) ApplyPatch(p *Patch) error {
+	next := s.Clone()
+
+	if len(p.Fields) > 0 {
+		data, err := json.Marshal(next)
+		if err != nil {
+			return err
+		}
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return err
+		}
+		for name, value := range p.Fields {
+			if bytes.Equal(value, []byte("null")) {
+				delete(fields, name)
+			} else {
+				fields[name] = value
+			}
+		}
+		if data, err = json.Marshal(fields); err != nil {
+			return err
+		}
+
+		patched := &State{}
+		if err := json.Unmarshal(data, patched); err != nil {
+			return err
+		}
+		next = patched
+	}
+
+	for _, change := range p.Hands {
+		pl := next.FindPlayerByID(change.PlayerID)
+		if pl == nil {
+			return fmt.Errorf("%w: unknown player %q", ErrPatchMismatch, change.PlayerID)
+		}
+		hand, ok := applyHandChange(pl.Hand, change)
+		if !ok {
+			return fmt.Errorf("%w: hand of %q", ErrPatchMismatch, change.PlayerID)
+		}
+		pl.Hand = hand
+	}
+
+	if p.DrawPile != nil {
+		cards, ok := applyPileChange(next.drawPileCards(), p.DrawPile)
+		if !ok {
+			return fmt.Errorf("%w: draw pile", ErrPatchMismatch)
+		}
+		next.DrawPile = &deck.Deck{Cards: cards}
+	}
+
+	if p.DiscardPile != nil {
+		cards, ok := applyPileChange(next.DiscardPile, p.DiscardPile)
+		if !ok {
+			return fmt.Errorf("%w: discard pile", ErrPatchMismatch)
+		}
+		next.DiscardPile = cards
+	}
+
+	next.clock = s.clock
+	next.events = s.events
+	*s = *next
+
+	return nil
+}
+
+// Equal checks if two states describe the same game position. Buffered
+// events and the time source are not compared.
+func (s *State) Equal(other *State) bool {
+	if other == nil {
+		return false
+	}
+
+	samePlayers := slices.EqualFunc(s.Players, other.Players, func(a, b *player.Player) bool {
+		return a.ID == b.ID && slices.Equal(a.Hand, b.Hand)
+	})
+	if !samePlayers {
+		return false
+	}
+
+	if !slices.Equal(s.drawPileCards(), other.drawPileCards()) || !slices.Equal(s.DiscardPile, other.DiscardPile) {
+		return false
+	}
+
+	a, errA := s.fields()
+	b, errB := other.fields()
+	return errA == nil && errB == nil && maps.EqualFunc(a, b, func(x, y json.RawMessage) bool {
+		return bytes.Equal(x, y)
+	})
+}
+
+// fields returns the JSON of every field not covered by hand and pile
+// changes. Empty values are left out, so nil and empty are the same.
+func (s *State) fields() (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	for name, value := range fields {
+		switch {
+		case slices.Contains(patchedFields, name):
+			delete(fields, name)
+		case bytes.Equal(value, []byte("null")), bytes.Equal(value, []byte("[]")), bytes.Equal(value, []byte("{}")):
+			delete(fields, name)
+		}
+	}
+
+	return fields, nil
+}
+
+// drawPileCards returns the cards of the draw pile, top first
+func (s *State) drawPileCards() []card.Card {
+	if s.DrawPile == nil {
+		return nil
+	}
+	return s.DrawPile.Cards
+}
+
+// diffHand returns the change between two hands, if any
+func diffHand(from, to []card.Card) (HandChange, bool) {
+	if slices.Equal(from, to) {
+		return HandChange{}, false
+	}
+
+	change := HandChange{}
+	added := slices.Clone(to)
+	for _, c := range from {
+		if i := slices.Index(added, c); i >= 0 {
+			added = slices.Delete(added, i, i+1)
+		} else {
+			change.Removed = append(change.Removed, c)
+		}
+	}
+	change.Added = added
+
+	if hand, ok := applyHandChange(from, change); !ok || !slices.Equal(hand, to) {
+		// The hand was reordered: send it whole
+		return HandChange{Hand: slices.Clone(to)}, true
+	}
+
+	return change, true
+}
+
+// applyHandChange returns the hand with the change applied
+func applyHandChange(hand []card.Card, change HandChange) ([]card.Card, bool) {
+	if change.Hand != nil {
+		return slices.Clone(change.Hand), true
+	}
+
+	hand = slices.Clone(hand)
+	for _, c := range change.Removed {
+		i := slices.Index(hand, c)
+		if i < 0 {
+			return nil, false
+		}
+		hand = slices.Delete(hand, i, i+1)
+	}
+
+	return append(hand, change.Added...), true
+}
+
+// diffPile returns the change between two piles, keeping as much of the
+// first pile as possible
+func diffPile(from, to []card.Card) *PileChange {
+	if slices.Equal(from, to) {
+		return nil
+	}
+
+	drop := 0
+	for drop < len(from) {
+		kept := from[drop:]
+		if len(kept) <= len(to) && slices.Equal(kept, to[:len(kept)]) {
+			break
+		}
+		drop++
+	}
+
+	return &PileChange{
+		Drop: drop,
+		Push: slices.Clone(to[len(from)-drop:]),
+	}
+}
+
+// applyPileChange returns the pile with the change applied
+func applyPileChange(pile []card.Card, change *PileChange) ([]card.Card, bool) {
+	if change.Drop > len(pile) {
+		return nil, false
+	}
+	return append(slices.Clone(pile[change.Drop:]), change.Push...), true
+}
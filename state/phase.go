@@ -0,0 +1,99 @@
+package state
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Phase is the step of the turn the game is waiting for
+type Phase string
+
+// Turn phases
+const (
+	PhaseAwaitingPlay           Phase = "AWAITING_PLAY"            // The current player must play or draw
+	PhaseAwaitingSuitChoice     Phase = "AWAITING_SUIT_CHOICE"     // The current player must declare a suit after a Jack
+	PhaseAwaitingAttackResponse Phase = "AWAITING_ATTACK_RESPONSE" // The current player must defend with an attack card or draw the penalty
+	PhaseFinished               Phase = "FINISHED"                 // The game is over
+)
+
+// phaseTransitions lists the phases each phase may lead to:
+//
+//	AwaitingPlay           -> AwaitingPlay (card played or drawn)
+//	                          AwaitingSuitChoice (Jack played)
+//	                          AwaitingAttackResponse (attack card played)
+//	                          Finished (last opponent went out)
+//	AwaitingSuitChoice     -> AwaitingPlay (suit declared)
+//	                          Finished (last opponent forfeited)
+//	AwaitingAttackResponse -> AwaitingAttackResponse (attack escalated)
+//	                          AwaitingPlay (penalty drawn)
+//	                          Finished (last opponent went out)
+//	Finished               -> nothing
+var phaseTransitions = map[Phase][]Phase{
+	PhaseAwaitingPlay:           {PhaseAwaitingPlay, PhaseAwaitingSuitChoice, PhaseAwaitingAttackResponse, PhaseFinished},
+	PhaseAwaitingSuitChoice:     {PhaseAwaitingPlay, PhaseFinished},
+	PhaseAwaitingAttackResponse: {PhaseAwaitingAttackResponse, PhaseAwaitingPlay, PhaseFinished},
+}
+
+// CurrentPhase returns the phase the game is in. A game with at most one
+// active player is always finished.
+func (s *State) CurrentPhase() Phase {
+	if s.IsGameOver() {
+		return PhaseFinished
+	}
+	return s.recordedPhase()
+}
+
+// recordedPhase returns the stored phase. States without one (built by hand
+// or saved before phases existed) derive it from the turn flags.
+func (s *State) recordedPhase() Phase {
+	switch {
+	case s.Phase != "":
+		return s.Phase
+	case s.LockedTurn:
+		return PhaseAwaitingSuitChoice
+	case s.InAttackChain:
+		return PhaseAwaitingAttackResponse
+	default:
+		return PhaseAwaitingPlay
+	}
+}
+
+// enterPhase moves the state to the given phase and keeps the turn flags in
+// sync with it. A finished game keeps the flags of its last position.
+// A transition missing from phaseTransitions leaves the state unchanged and
+// returns ErrInvalidState: only states built by hand without Validate reach one.
+func (s *State) enterPhase(to Phase) error {
+	from := s.recordedPhase()
+	if !slices.Contains(phaseTransitions[from], to) {
+		return invalidState("illegal phase transition from %s to %s", from, to)
+	}
+
+	s.Phase = to
+	if to == PhaseFinished {
+		return nil
+	}
+
+	s.LockedTurn = to == PhaseAwaitingSuitChoice
+	s.InAttackChain = to == PhaseAwaitingAttackResponse
+	if !s.InAttackChain {
+		s.AttackAmount = 0
+	}
+	return nil
+}
+
+// checkPhase verifies that the move is allowed in the current phase
+func (s *State) checkPhase(m Move, allowed ...Phase) *RuleViolation {
+	phase := s.CurrentPhase()
+	switch {
+	case slices.Contains(allowed, phase):
+		return nil
+	case phase == PhaseFinished:
+		return newViolation(ErrGameOver, m, "")
+	case phase == PhaseAwaitingSuitChoice:
+		return newViolation(ErrTurnLocked, m, "turn is locked until a suit is declared")
+	case slices.Contains(allowed, PhaseAwaitingSuitChoice):
+		return newViolation(ErrTurnNotLocked, m, "")
+	default:
+		return newViolation(ErrWrongPhase, m, fmt.Sprintf("move not allowed while %s", phase))
+	}
+}
@@ -0,0 +1,64 @@
+package state
+
+import (
+	"math/rand"
+)
+
+// RNG is a seeded random number generator whose stream position is part of
+// the game state. Restoring an RNG from its seed and position yields exactly
+// the values the original would have produced next.
+type RNG struct {
+	Seed     int64  `json:"seed"`
+	Position uint64 `json:"position"` // Number of values drawn from the source so far
+
+	src rand.Source64
+	rnd *rand.Rand
+}
+
+// NewRNG creates a new RNG with the given seed
+func NewRNG(seed int64) *RNG {
+	return &RNG{Seed: seed}
+}
+
+// Rand returns a *rand.Rand that draws from this generator and advances its position
+func (g *RNG) Rand() *rand.Rand {
+	if g.rnd == nil {
+		g.restore()
+	}
+	return g.rnd
+}
+
+// Clone returns a copy of the generator at the same position
+func (g *RNG) Clone() *RNG {
+	return &RNG{Seed: g.Seed, Position: g.Position}
+}
+
+// restore rebuilds the underlying source and fast-forwards it to the saved position
+func (g *RNG) restore() {
+	g.src = rand.NewSource(g.Seed).(rand.Source64)
+	for i := uint64(0); i < g.Position; i++ {
+		g.src.Int63()
+	}
+	g.rnd = rand.New(countingSource{g})
+}
+
+// countingSource forwards to the generator's source while counting every value drawn
+type countingSource struct {
+	rng *RNG
+}
+
+func (c countingSource) Int63() int64 {
+	c.rng.Position++
+	return c.rng.src.Int63()
+}
+
+func (c countingSource) Uint64() uint64 {
+	c.rng.Position++
+	return c.rng.src.Uint64()
+}
+
+func (c countingSource) Seed(seed int64) {
+	c.rng.Seed = seed
+	c.rng.Position = 0
+	c.rng.src.Seed(seed)
+}
@@ -0,0 +1,172 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/djoufson/check-games-engine/card"
+	"github.com/djoufson/check-games-engine/deck"
+	"github.com/djoufson/check-games-engine/player"
+)
+
+// SchemaVersion is the version of the serialized state written by ToJSON
+const SchemaVersion = 3
+
+// envelope is the serialized form of a state, tagged with its schema version
+type envelope struct {
+	Version *int            `json:"version"`
+	State   json.RawMessage `json:"state"`
+}
+
+// migration upgrades the fields of a serialized state to the next version
+type migration func(fields map[string]json.RawMessage) error
+
+// migrations holds the upgrade of each older version to the one after it.
+// Saves without an envelope are version 1.
+var migrations = map[int]migration{
+	1: migrateV1,
+	2: migrateV2,
+}
+
+// ToJSON serializes the game state to JSON, tagged with the schema version
+func (s *State) ToJSON() ([]byte, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+
+	version := SchemaVersion
+	return json.Marshal(envelope{Version: &version, State: data})
+}
+
+// FromJSON deserializes the game state from JSON and validates it. States
+// saved by older versions of the engine are migrated; unknown versions are
+// rejected with ErrUnsupportedVersion.
+func FromJSON(data []byte) (*State, error) {
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, err
+	}
+
+	version := 1
+	raw := json.RawMessage(data)
+	if env.Version != nil {
+		version = *env.Version
+		raw = env.State
+	}
+	if version < 1 || version > SchemaVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, version)
+	}
+
+	if version < SchemaVersion {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return nil, err
+		}
+		for v := version; v < SchemaVersion; v++ {
+			if err := migrations[v](fields); err != nil {
+				return nil, fmt.Errorf("migrating state from version %d: %w", v, err)
+			}
+		}
+
+		var err error
+		if raw, err = json.Marshal(fields); err != nil {
+			return nil, err
+		}
+	}
+
+	state := &State{}
+	if err := json.Unmarshal(raw, state); err != nil {
+		return nil, err
+	}
+
+	if err := state.Validate(); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// migrateV1 renames blocked_turn to locked_turn and names the direction
+func migrateV1(fields map[string]json.RawMessage) error {
+	if locked, ok := fields["blocked_turn"]; ok {
+		fields["locked_turn"] = locked
+		delete(fields, "blocked_turn")
+	}
+
+	if raw, ok := fields["direction"]; ok {
+		var direction int
+		if err := json.Unmarshal(raw, &direction); err != nil {
+			return err
+		}
+		name, ok := directionNames[Direction(direction)]
+		if !ok {
+			return fmt.Errorf("unknown direction %d", direction)
+		}
+		value, err := json.Marshal(name)
+		if err != nil {
+			return err
+		}
+		fields["direction"] = value
+	}
+
+	return nil
+}
+
+// migrateV2 numbers the cards of saves made before cards had instance IDs:
+// hands in seat order, then the draw pile, then the discard pile
+func migrateV2(fields map[string]json.RawMessage) error {
+	var players []*player.Player
+	drawPile := &deck.Deck{}
+	var discardPile []card.Card
+	for name, target := range map[string]any{"players": &players, "draw_pile": drawPile, "discard_pile": &discardPile} {
+		if raw, ok := fields[name]; ok {
+			if err := json.Unmarshal(raw, target); err != nil {
+				return err
+			}
+		}
+	}
+
+	nextID := 1
+	number := func(cards []card.Card) {
+		for i := range cards {
+			cards[i].ID = nextID
+			nextID++
+		}
+	}
+	for _, p := range players {
+		if p != nil {
+			number(p.Hand)
+		}
+	}
+	number(drawPile.Cards)
+	number(discardPile)
+
+	updates := map[string]any{"players": players, "draw_pile": drawPile, "discard_pile": discardPile}
+	if len(discardPile) > 0 {
+		// The top card is the last discard: it takes that card's ID
+		var topCard card.Card
+		if raw, ok := fields["top_card"]; ok {
+			if err := json.Unmarshal(raw, &topCard); err != nil {
+				return err
+			}
+		}
+		last := discardPile[len(discardPile)-1]
+		if !topCard.Matches(last) {
+			return invalidState("top card %s is not the last discard %s", topCard, last)
+		}
+		updates["top_card"] = last
+	}
+	for name, value := range updates {
+		if _, ok := fields[name]; !ok && name != "top_card" {
+			continue
+		}
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		fields[name] = raw
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package state
+
+import (
+	"slices"
+
+	"github.com/djoufson/check-games-engine/card"
+)
+
+// Placement records where and when a player finished
+type Placement struct {
+	PlayerID  string `json:"player_id"`
+	Place     int    `json:"place"`               // 1 for the first player to go out
+	Turn      int    `json:"turn"`                // Turn on which the player finished, counting from 1
+	Forfeited bool   `json:"forfeited,omitempty"` // Whether the player left the game as a loss
+}
+
+// Standings returns the placements recorded so far, best first. Players who
+// forfeited take the last places, and once the game is over the last player
+// with cards is placed behind everyone who went out.
+func (s *State) Standings() []Placement {
+	standings := slices.Clone(s.Placements)
+	slices.SortStableFunc(standings, func(a, b Placement) int {
+		return a.Place - b.Place
+	})
+	return standings
+}
+
+// CurrentTurn returns the number of the turn being played, counting from 1
+func (s *State) CurrentTurn() int {
+	return s.TurnsPlayed + 1
+}
+
+// recordPlacement gives the player the best place still open
+func (s *State) recordPlacement(playerID string) {
+	place := 1
+	for _, placement := range s.Placements {
+		if !placement.Forfeited {
+			place++
+		}
+	}
+
+	s.Placements = append(s.Placements, Placement{
+		PlayerID: playerID,
+		Place:    place,
+		Turn:     s.CurrentTurn(),
+	})
+}
+
+// recordForfeit gives the player the worst place still open
+func (s *State) recordForfeit(playerID string) {
+	place := len(s.Players)
+	for _, placement := range s.Placements {
+		if placement.Forfeited {
+			place--
+		}
+	}
+
+	s.Placements = append(s.Placements, Placement{
+		PlayerID:  playerID,
+		Place:     place,
+		Turn:      s.CurrentTurn(),
+		Forfeited: true,
+	})
+}
+
+// ForfeitedHand returns the cards the player gave up when they forfeited
+func (s *State) ForfeitedHand(playerID string) []card.Card {
+	return slices.Clone(s.ForfeitedHands[playerID])
+}
+
+// hasForfeited checks if the player left the game as a loss
+func (s *State) hasForfeited(playerID string) bool {
+	return slices.ContainsFunc(s.Placements, func(placement Placement) bool {
+		return placement.PlayerID == playerID && placement.Forfeited
+	})
+}
+
+// endTurn counts a completed turn and charges its time to the player's clock
+func (s *State) endTurn(playerID string) {
+	s.TurnsPlayed++
+	s.chargeClock(playerID)
+}
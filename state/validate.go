@@ -0,0 +1,153 @@
+package state
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/djoufson/check-games-engine/card"
+	"github.com/djoufson/check-games-engine/deck"
+)
+
+// Validate checks the invariants of the state: every card of the shoe is in
+// exactly one place with its own instance ID, the top card is the last
+// discard, the players are consistent, and the phase agrees with the turn
+// flags and the attack fields. A state that fails validation was corrupted or
+// tampered with and cannot be played safely.
+func (s *State) Validate() error {
+	if err := s.validatePlayers(); err != nil {
+		return err
+	}
+
+	if err := s.validateCards(); err != nil {
+		return err
+	}
+
+	if len(s.DiscardPile) == 0 {
+		return invalidState("discard pile is empty")
+	}
+	if top := s.DiscardPile[len(s.DiscardPile)-1]; top != s.TopCard {
+		return invalidState("top card %s is not the last discard %s", s.TopCard, top)
+	}
+
+	if s.InAttackChain != (s.AttackAmount > 0) {
+		return invalidState("attack amount %d does not match attack chain %v", s.AttackAmount, s.InAttackChain)
+	}
+
+	if err := s.validatePhase(); err != nil {
+		return err
+	}
+
+	if s.Ruleset != nil {
+		if err := s.Ruleset.Validate(); err != nil {
+			return invalidState("%v", err)
+		}
+	}
+
+	return nil
+}
+
+// validatePlayers checks the player IDs, the active players and the current player
+func (s *State) validatePlayers() error {
+	ids := make([]string, 0, len(s.Players))
+	for _, p := range s.Players {
+		if p == nil {
+			return invalidState("missing player")
+		}
+		if slices.Contains(ids, p.ID) {
+			return invalidState("duplicate player ID %q", p.ID)
+		}
+		ids = append(ids, p.ID)
+	}
+
+	for i, id := range s.ActivePlayers {
+		if !slices.Contains(ids, id) {
+			return invalidState("active player %q is not a player", id)
+		}
+		if slices.Contains(s.ActivePlayers[:i], id) {
+			return invalidState("duplicate active player %q", id)
+		}
+	}
+
+	if !s.IsGameOver() && !s.IsPlayerActive(s.CurrentPlayerId) {
+		return invalidState("current player %q is not active", s.CurrentPlayerId)
+	}
+
+	return nil
+}
+
+// validatePhase checks that the phase is known, agrees with the turn flags
+// and is only finished once the game is over. A finished game keeps the
+// flags of its last position.
+func (s *State) validatePhase() error {
+	phase := s.recordedPhase()
+	if _, ok := phaseTransitions[phase]; !ok && phase != PhaseFinished {
+		return invalidState("unknown phase %q", phase)
+	}
+
+	if s.LockedTurn && s.InAttackChain {
+		return invalidState("turn is locked during an attack chain")
+	}
+
+	if phase == PhaseFinished {
+		if !s.IsGameOver() {
+			return invalidState("phase %s with %d active players", phase, len(s.ActivePlayers))
+		}
+		return nil
+	}
+
+	if s.LockedTurn != (phase == PhaseAwaitingSuitChoice) {
+		return invalidState("locked turn %v does not match phase %s", s.LockedTurn, phase)
+	}
+	if s.InAttackChain != (phase == PhaseAwaitingAttackResponse) {
+		return invalidState("attack chain %v does not match phase %s", s.InAttackChain, phase)
+	}
+
+	return nil
+}
+
+// validateCards checks that the hands and piles hold every card of the shoe
+// exactly once and that no two cards share an instance ID
+func (s *State) validateCards() error {
+	decks := max(s.Decks, 1)
+	cards := make([]card.Card, 0, decks*deck.Size)
+	for _, p := range s.Players {
+		cards = append(cards, p.Hand...)
+	}
+	for _, hand := range s.ForfeitedHands {
+		cards = append(cards, hand...)
+	}
+	if s.DrawPile != nil {
+		cards = append(cards, s.DrawPile.Cards...)
+	}
+	cards = append(cards, s.DiscardPile...)
+
+	counts := make(map[card.Card]int)
+	ids := make(map[int]bool)
+	for _, c := range cards {
+		counts[c.Face()]++
+		if c.ID == 0 {
+			continue
+		}
+		if ids[c.ID] {
+			return invalidState("card ID %d is used twice", c.ID)
+		}
+		ids[c.ID] = true
+	}
+
+	for _, c := range deck.New().Cards {
+		if count := counts[c.Face()]; count != decks {
+			return invalidState("card %s appears %d times instead of %d", c, count, decks)
+		}
+		delete(counts, c.Face())
+	}
+	for c := range counts {
+		return invalidState("unknown card %s", c)
+	}
+
+	return nil
+}
+
+// invalidState creates an error wrapping ErrInvalidState
+func invalidState(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidState, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,98 @@
+package state
+
+import (
+	"slices"
+
+	"github.com/djoufson/check-games-engine/card"
+	"github.com/djoufson/check-games-engine/rules"
+)
+
+// View is the part of the game a player or a spectator may see. Opponents'
+// hands are reduced to their size and the draw pile to its count, so a view
+// can be sent to clients as is.
+type View struct {
+	ViewerID         string         `json:"viewer_id,omitempty"` // Player the view is for; empty for spectators
+	Hand             []card.Card    `json:"hand,omitempty"`      // The viewer's own hand
+	Players          []PlayerView   `json:"players"`
+	CurrentPlayerId  string         `json:"current_player_id"`
+	Clocks           *Clocks        `json:"clocks,omitempty"`
+	Direction        Direction      `json:"direction"`
+	TopCard          card.Card      `json:"top_card"`
+	EffectiveTopCard card.Card      `json:"effective_top_card"` // Card to match, seen through transparent cards
+	ActiveSuit       card.Suit      `json:"active_suit"`        // Suit to follow, including one declared with a Jack
+	DrawPileCount    int            `json:"draw_pile_count"`
+	InAttackChain    bool           `json:"in_attack_chain"`
+	AttackAmount     int            `json:"attack_amount"`
+	Phase            Phase          `json:"phase"`
+	TurnsPlayed      int            `json:"turns_played"`
+	Placements       []Placement    `json:"placements,omitempty"`
+	Ruleset          *rules.Ruleset `json:"ruleset,omitempty"`
+	CheckAnnounced   []string       `json:"check_announced,omitempty"`
+	CheckExposed     string         `json:"check_exposed,omitempty"`
+}
+
+// PlayerView is what everyone may see of a player
+type PlayerView struct {
+	ID       string `json:"id"`
+	HandSize int    `json:"hand_size"`
+	Active   bool   `json:"active"` // Whether the player is still in the game
+}
+
+// ViewFor returns the game as seen by the given player
+func (s *State) ViewFor(playerID string) (*View, error) {
+	p := s.FindPlayerByID(playerID)
+	if p == nil {
+		return nil, ErrPlayerNotFound
+	}
+
+	v := s.SpectatorView()
+	v.ViewerID = playerID
+	v.Hand = slices.Clone(p.Hand)
+
+	return v, nil
+}
+
+// SpectatorView returns the game as seen by someone who holds no cards
+func (s *State) SpectatorView() *View {
+	players := make([]PlayerView, len(s.Players))
+	for i, p := range s.Players {
+		players[i] = PlayerView{
+			ID:       p.ID,
+			HandSize: p.HandSize(),
+			Active:   s.IsPlayerActive(p.ID),
+		}
+	}
+
+	drawPileCount := 0
+	if s.DrawPile != nil {
+		drawPileCount = s.DrawPile.Count()
+	}
+
+	v := &View{
+		Players:          players,
+		CurrentPlayerId:  s.CurrentPlayerId,
+		Direction:        s.Direction,
+		TopCard:          s.TopCard,
+		EffectiveTopCard: s.EffectiveTopCard(),
+		ActiveSuit:       s.ActiveSuit(),
+		DrawPileCount:    drawPileCount,
+		InAttackChain:    s.InAttackChain,
+		AttackAmount:     s.AttackAmount,
+		Phase:            s.CurrentPhase(),
+		TurnsPlayed:      s.TurnsPlayed,
+		Placements:       slices.Clone(s.Placements),
+		CheckAnnounced:   slices.Clone(s.CheckAnnounced),
+		CheckExposed:     s.CheckExposed,
+	}
+
+	if s.Clocks != nil {
+		v.Clocks = s.Clocks.Clone()
+	}
+
+	if s.Ruleset != nil {
+		ruleset := s.Ruleset.Clone()
+		v.Ruleset = &ruleset
+	}
+
+	return v
+}
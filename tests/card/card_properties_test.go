@@ -116,3 +116,29 @@ func TestShouldCalculateCorrectDrawPenalty_WhenCheckingWildCards(t *testing.T) {
 		t.Errorf("Expected normal card to have draw penalty of 0, got %d", normalCard.GetDrawPenalty())
 	}
 }
+
+// TestShouldMatchByFaceOrInstance_WhenComparingCards tests Matches with and without instance IDs
+func TestShouldMatchByFaceOrInstance_WhenComparingCards(t *testing.T) {
+	// Arrange
+	face := card.NewCard(card.Hearts, card.Seven)
+	first, second := face, face
+	first.ID = 1
+	second.ID = 2
+
+	// Act & Assert
+	if !face.Matches(first) || !first.Matches(face) {
+		t.Error("Expected a face value to match any instance of it")
+	}
+
+	if first.Matches(second) {
+		t.Error("Expected different instances not to match")
+	}
+
+	if face.Matches(card.NewCard(card.Spades, card.Seven)) {
+		t.Error("Expected different faces not to match")
+	}
+
+	if first.Face() != face {
+		t.Errorf("Expected Face to drop the ID, got %+v", first.Face())
+	}
+}
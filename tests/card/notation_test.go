@@ -0,0 +1,129 @@
+package card_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/djoufson/check-games-engine/card"
+)
+
+// TestShouldParseCard_WhenUsingCompactNotation tests parsing every kind of notation
+func TestShouldParseCard_WhenUsingCompactNotation(t *testing.T) {
+	tests := []struct {
+		notation string
+		want     card.Card
+	}{
+		{"7H", card.NewCard(card.Hearts, card.Seven)},
+		{"JS", card.NewCard(card.Spades, card.Jack)},
+		{"AD", card.NewCard(card.Diamonds, card.Ace)},
+		{"10C", card.NewCard(card.Clubs, card.Ten)},
+		{"qh", card.NewCard(card.Hearts, card.Queen)},
+		{"K♠", card.NewCard(card.Spades, card.King)},
+		{"JKR", card.NewRedJoker()},
+		{"jkb", card.NewBlackJoker()},
+	}
+
+	for _, tt := range tests {
+		// Act
+		got, err := card.Parse(tt.notation)
+
+		// Assert
+		if err != nil {
+			t.Errorf("Failed to parse %q: %v", tt.notation, err)
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf("Expected %q to be %v, got %v", tt.notation, tt.want, got)
+		}
+	}
+}
+
+// TestShouldReturnError_WhenNotationIsInvalid tests rejecting malformed notations
+func TestShouldReturnError_WhenNotationIsInvalid(t *testing.T) {
+	for _, notation := range []string{"", "H", "1H", "11S", "7X", "JK", "SEVEN of HEARTS"} {
+		// Act
+		_, err := card.Parse(notation)
+
+		// Assert
+		if !errors.Is(err, card.ErrInvalidNotation) {
+			t.Errorf("Expected ErrInvalidNotation for %q, got %v", notation, err)
+		}
+	}
+}
+
+// TestShouldRoundTrip_WhenFormattingEveryCard tests that Short and Parse agree on the whole deck
+func TestShouldRoundTrip_WhenFormattingEveryCard(t *testing.T) {
+	suits := []card.Suit{card.Spades, card.Hearts, card.Diamonds, card.Clubs}
+	ranks := []card.Rank{
+		card.Ace, card.Two, card.Three, card.Four, card.Five, card.Six, card.Seven,
+		card.Eight, card.Nine, card.Ten, card.Jack, card.Queen, card.King,
+	}
+	cards := []card.Card{card.NewRedJoker(), card.NewBlackJoker()}
+	for _, suit := range suits {
+		for _, rank := range ranks {
+			cards = append(cards, card.NewCard(suit, rank))
+		}
+	}
+
+	for _, c := range cards {
+		// Act
+		ascii, asciiErr := card.Parse(c.Short())
+		symbol, symbolErr := card.Parse(c.ShortUnicode())
+
+		// Assert
+		if asciiErr != nil || ascii != c {
+			t.Errorf("Expected %q to parse back to %v, got %v (%v)", c.Short(), c, ascii, asciiErr)
+		}
+
+		if symbolErr != nil || symbol != c {
+			t.Errorf("Expected %q to parse back to %v, got %v (%v)", c.ShortUnicode(), c, symbol, symbolErr)
+		}
+	}
+}
+
+// TestShouldFormatShortNotation_WhenUsingUnicodeSuits tests the Unicode output
+func TestShouldFormatShortNotation_WhenUsingUnicodeSuits(t *testing.T) {
+	// Arrange
+	ten := card.NewCard(card.Diamonds, card.Ten)
+
+	// Act
+	result := ten.ShortUnicode()
+
+	// Assert
+	if result != "10♦" {
+		t.Errorf("Expected '10♦', got '%s'", result)
+	}
+}
+
+// TestShouldParseHand_WhenCardsAreSeparated tests parsing and formatting whole hands
+func TestShouldParseHand_WhenCardsAreSeparated(t *testing.T) {
+	// Arrange
+	want := []card.Card{
+		card.NewCard(card.Hearts, card.Seven),
+		card.NewCard(card.Spades, card.Jack),
+		card.NewCard(card.Clubs, card.Two),
+		card.NewRedJoker(),
+	}
+
+	// Act
+	hand, err := card.ParseHand(" 7H JS,2C\tJKR ")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to parse hand: %v", err)
+	}
+
+	if !slices.Equal(hand, want) {
+		t.Errorf("Expected %v, got %v", want, hand)
+	}
+
+	if formatted := card.FormatHand(hand); formatted != "7H JS 2C JKR" {
+		t.Errorf("Expected '7H JS 2C JKR', got '%s'", formatted)
+	}
+
+	if _, err := card.ParseHand("7H ZZ"); !errors.Is(err, card.ErrInvalidNotation) {
+		t.Errorf("Expected ErrInvalidNotation for a bad card, got %v", err)
+	}
+}
@@ -77,3 +77,44 @@ func TestShouldContainBothColoredJokers_WhenCreatingNewDeck(t *testing.T) {
 		t.Error("No black joker found in deck")
 	}
 }
+
+// TestShouldNumberCards_WhenCreatingDeck tests that every card of a new deck has its own instance ID
+func TestShouldNumberCards_WhenCreatingDeck(t *testing.T) {
+	// Arrange & Act
+	d := deck.New()
+
+	// Assert
+	seen := make(map[int]bool)
+	for _, c := range d.Cards {
+		if c.ID <= 0 || seen[c.ID] {
+			t.Fatalf("Expected a unique positive ID, got %+v", c)
+		}
+		seen[c.ID] = true
+	}
+}
+
+// TestShouldCombineDecks_WhenCreatingShoe tests that a shoe holds n copies of every card with unique IDs
+func TestShouldCombineDecks_WhenCreatingShoe(t *testing.T) {
+	// Arrange & Act
+	shoe := deck.NewShoe(3)
+
+	// Assert
+	if shoe.Count() != 3*deck.Size {
+		t.Fatalf("Expected %d cards, got %d", 3*deck.Size, shoe.Count())
+	}
+
+	faces := make(map[card.Card]int)
+	ids := make(map[int]bool)
+	for _, c := range shoe.Cards {
+		faces[c.Face()]++
+		if ids[c.ID] {
+			t.Fatalf("Expected unique IDs, got %d twice", c.ID)
+		}
+		ids[c.ID] = true
+	}
+	for face, count := range faces {
+		if count != 3 {
+			t.Errorf("Expected 3 copies of %v, got %d", face, count)
+		}
+	}
+}
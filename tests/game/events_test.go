@@ -0,0 +1,60 @@
+package game_test
+
+import (
+	"testing"
+
+	"github.com/djoufson/check-games-engine/state"
+)
+
+// TestShouldNotifySubscribers_WhenMoveIsApplied tests event subscriptions on a game
+func TestShouldNotifySubscribers_WhenMoveIsApplied(t *testing.T) {
+	// Arrange
+	g := setupGameplayTest(t)
+	var received []state.Event
+	g.Subscribe(func(e state.Event) {
+		received = append(received, e)
+	})
+
+	// Act
+	err := g.DrawCard("player1")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to draw card: %v", err)
+	}
+
+	if len(received) != 1 || received[0].Type != state.EventCardsDrawn {
+		t.Fatalf("Expected a single cards drawn event, got %v", received)
+	}
+
+	if received[0].PlayerID != "player1" || received[0].Amount != 1 {
+		t.Errorf("Unexpected cards drawn event %+v", received[0])
+	}
+}
+
+// TestShouldCollectEvents_WhenDrainingGameEvents tests collecting events across moves
+func TestShouldCollectEvents_WhenDrainingGameEvents(t *testing.T) {
+	// Arrange
+	g := setupGameplayTest(t)
+
+	// Act
+	g.DrawCard("player1")
+	g.DrawCard("player2")
+	g.DrawCard("player2") // Rejected: it is player1's turn again
+	events := g.DrainEvents()
+
+	// Assert
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %v", events)
+	}
+
+	for _, e := range events {
+		if e.Type != state.EventCardsDrawn {
+			t.Errorf("Expected only cards drawn events, got %v", e.Type)
+		}
+	}
+
+	if len(g.DrainEvents()) != 0 {
+		t.Error("Expected events to be cleared after draining")
+	}
+}
@@ -1,10 +1,13 @@
 package game_test
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/djoufson/check-games-engine/card"
 	"github.com/djoufson/check-games-engine/game"
+	"github.com/djoufson/check-games-engine/state"
 )
 
 // setupGameplayTest creates a new game for testing gameplay flow
@@ -116,3 +119,162 @@ func TestShouldSwitchTurns_WhenPlayingCard(t *testing.T) {
 		t.Errorf("Expected current player to be player2, got %s", g.CurrentPlayerID())
 	}
 }
+
+// TestShouldReturnEmptyPlayableCards_WhenNotPlayersTurn tests playable cards for a waiting player
+func TestShouldReturnEmptyPlayableCards_WhenNotPlayersTurn(t *testing.T) {
+	// Arrange
+	g := setupGameplayTest(t)
+
+	// Act
+	playable, err := g.GetPlayableCards("player2")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error for a waiting player, got %v", err)
+	}
+
+	if len(playable) != 0 {
+		t.Errorf("Expected no playable cards, got %v", playable)
+	}
+
+	if moves := g.LegalMoves("player2"); len(moves) != 0 {
+		t.Errorf("Expected no legal moves, got %v", moves)
+	}
+}
+
+// TestShouldApplyEveryLegalMove_WhenPlayingThroughGame tests that Game.LegalMoves agrees with Game.Apply
+func TestShouldApplyEveryLegalMove_WhenPlayingThroughGame(t *testing.T) {
+	// Arrange
+	g := setupGameplayTest(t)
+
+	for turn := 0; turn < 100 && !g.IsGameOver(); turn++ {
+		moves := g.LegalMoves(g.CurrentPlayerID())
+		if len(moves) == 0 {
+			t.Fatalf("Expected at least one legal move on turn %d", turn)
+		}
+
+		// Act
+		err := g.Apply(moves[0])
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Failed to apply legal move on turn %d: %v", turn, err)
+		}
+	}
+}
+
+// TestShouldRejectMoves_WhenGameIsFinished tests the finished phase through the game API
+func TestShouldRejectMoves_WhenGameIsFinished(t *testing.T) {
+	// Arrange
+	g := setupGameplayTest(t)
+	for turn := 0; turn < 500 && !g.IsGameOver(); turn++ {
+		if err := g.Apply(g.LegalMoves(g.CurrentPlayerID())[0]); err != nil {
+			t.Fatalf("Failed to apply legal move on turn %d: %v", turn, err)
+		}
+	}
+	if !g.IsGameOver() {
+		t.Fatal("Expected the game to finish within the turn limit")
+	}
+
+	// Act
+	err := g.DrawCard(g.GetLoser())
+
+	// Assert
+	if !errors.Is(err, state.ErrGameOver) {
+		t.Errorf("Expected ErrGameOver, got %v", err)
+	}
+
+	if g.GetPhase() != state.PhaseFinished {
+		t.Errorf("Expected phase %s, got %s", state.PhaseFinished, g.GetPhase())
+	}
+}
+
+// TestShouldListEveryPlayer_WhenGameIsFinished tests the standings through the game API
+func TestShouldListEveryPlayer_WhenGameIsFinished(t *testing.T) {
+	// Arrange
+	g := setupGameplayTest(t)
+
+	// Act
+	for turn := 0; turn < 500 && !g.IsGameOver(); turn++ {
+		if err := g.Apply(g.LegalMoves(g.CurrentPlayerID())[0]); err != nil {
+			t.Fatalf("Failed to apply legal move on turn %d: %v", turn, err)
+		}
+	}
+
+	// Assert
+	standings := g.Standings()
+	if len(standings) != len(g.State().Players) {
+		t.Fatalf("Expected a placement for each player, got %v", standings)
+	}
+
+	if standings[0].PlayerID != g.GetWinners()[0] || standings[0].Place != 1 {
+		t.Errorf("Expected the winner in first place, got %v", standings[0])
+	}
+
+	if last := standings[len(standings)-1]; last.PlayerID != g.GetLoser() || last.Place != len(standings) {
+		t.Errorf("Expected the loser in last place, got %v", last)
+	}
+}
+
+// TestShouldEndGame_WhenOpponentForfeits tests leaving a two-player game through the game API
+func TestShouldEndGame_WhenOpponentForfeits(t *testing.T) {
+	// Arrange
+	g := setupGameplayTest(t)
+
+	// Act
+	err := g.Forfeit("player2")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to forfeit: %v", err)
+	}
+
+	if !g.IsGameOver() || g.GetLoser() != "player2" {
+		t.Errorf("Expected player2 to lose the finished game, got over=%v loser=%s", g.IsGameOver(), g.GetLoser())
+	}
+
+	if hand, _ := g.GetPlayerHand("player2"); len(hand) != 0 {
+		t.Errorf("Expected player2's hand to be returned, got %d cards", len(hand))
+	}
+}
+
+// manualClock is a clock that only moves when told to
+type manualClock struct {
+	now time.Time
+}
+
+func (c *manualClock) Now() time.Time {
+	return c.now
+}
+
+// TestShouldApplyTimeout_WhenTurnClockRunsOut tests turn clocks through the game API
+func TestShouldApplyTimeout_WhenTurnClockRunsOut(t *testing.T) {
+	// Arrange
+	clock := &manualClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	g, err := game.New([]string{"player1", "player2"}, &game.Options{
+		RandomSeed:  12345,
+		TimeControl: &state.TimeControl{PerMove: 30 * time.Second, OnTimeout: state.TimeoutPass},
+		Clock:       clock,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	deadline, ok := g.TurnDeadline()
+	if !ok || !deadline.Equal(clock.now.Add(30*time.Second)) {
+		t.Fatalf("Expected a deadline in 30s, got %v (%v)", deadline, ok)
+	}
+	clock.now = deadline
+
+	// Act
+	err = g.Timeout("player1")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to apply timeout: %v", err)
+	}
+
+	if current := g.CurrentPlayerID(); current != "player2" {
+		t.Errorf("Expected player2 to play after player1 ran out of time, got %s", current)
+	}
+}
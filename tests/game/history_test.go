@@ -0,0 +1,250 @@
+package game_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/djoufson/check-games-engine/card"
+	"github.com/djoufson/check-games-engine/deck"
+	"github.com/djoufson/check-games-engine/game"
+	"github.com/djoufson/check-games-engine/player"
+	"github.com/djoufson/check-games-engine/state"
+)
+
+// setupHistoryTest creates a game with a Jack, an attack card and a nearly empty draw pile
+func setupHistoryTest() *game.Game {
+	player1 := player.New("player1")
+	player1.AddCardsToHand([]card.Card{
+		card.NewCard(card.Hearts, card.Jack),
+		card.NewCard(card.Hearts, card.Seven),
+		card.NewCard(card.Clubs, card.Four),
+	})
+
+	player2 := player.New("player2")
+	player2.AddCardsToHand([]card.Card{
+		card.NewCard(card.Spades, card.Seven),
+		card.NewCard(card.Diamonds, card.Four),
+	})
+
+	discardPile := []card.Card{
+		card.NewCard(card.Diamonds, card.Three),
+		card.NewCard(card.Clubs, card.Eight),
+		card.NewCard(card.Spades, card.Nine),
+		card.NewCard(card.Hearts, card.Queen),
+	}
+
+	stateObj := &state.State{
+		Players:         []*player.Player{player1, player2},
+		ActivePlayers:   []string{player1.ID, player2.ID},
+		CurrentPlayerId: player1.ID,
+		Direction:       state.Clockwise,
+		DrawPile:        &deck.Deck{Cards: []card.Card{card.NewCard(card.Clubs, card.King)}},
+		DiscardPile:     discardPile,
+		TopCard:         discardPile[len(discardPile)-1],
+		LastActiveSuit:  card.Hearts,
+		RNG:             state.NewRNG(99),
+	}
+
+	return game.FromState(stateObj)
+}
+
+// mustJSON serializes the game or fails the test
+func mustJSON(t *testing.T, g *game.Game) []byte {
+	t.Helper()
+	data, err := g.ToJSON()
+	if err != nil {
+		t.Fatalf("Failed to serialize game: %v", err)
+	}
+	return data
+}
+
+// TestShouldRestorePreviousState_WhenUndoingMove tests undoing a single move
+func TestShouldRestorePreviousState_WhenUndoingMove(t *testing.T) {
+	// Arrange
+	g := setupHistoryTest()
+	initial := mustJSON(t, g)
+	if err := g.PlayCard("player1", card.NewCard(card.Clubs, card.Four)); err == nil {
+		t.Fatal("Expected Four of Clubs to be rejected on the Queen of Hearts")
+	}
+	if err := g.DrawCard("player1"); err != nil {
+		t.Fatalf("Failed to draw card: %v", err)
+	}
+
+	// Act
+	err := g.Undo()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to undo: %v", err)
+	}
+
+	if !bytes.Equal(initial, mustJSON(t, g)) {
+		t.Error("Expected undo to restore the initial state")
+	}
+
+	if len(g.History()) != 0 {
+		t.Errorf("Expected empty history after undo, got %v", g.History())
+	}
+
+	if err := g.Undo(); !errors.Is(err, game.ErrNothingToUndo) {
+		t.Errorf("Expected ErrNothingToUndo, got %v", err)
+	}
+}
+
+// TestShouldRestoreLockedTurn_WhenUndoingSuitDeclaration tests undoing a suit declaration
+func TestShouldRestoreLockedTurn_WhenUndoingSuitDeclaration(t *testing.T) {
+	// Arrange
+	g := setupHistoryTest()
+	g.PlayCard("player1", card.NewCard(card.Hearts, card.Jack))
+	g.ChangeSuit("player1", card.Diamonds)
+
+	// Act
+	err := g.Undo()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to undo: %v", err)
+	}
+
+	if !g.State().LockedTurn {
+		t.Error("Expected the turn to be locked again after undoing the suit declaration")
+	}
+
+	if g.CurrentPlayerID() != "player1" {
+		t.Errorf("Expected player1 to still have to declare a suit, got %s", g.CurrentPlayerID())
+	}
+
+	if err := g.ChangeSuit("player1", card.Clubs); err != nil {
+		t.Errorf("Expected to be able to declare a suit again, got %v", err)
+	}
+}
+
+// TestShouldRestoreAttackChain_WhenUndoingPenaltyDraw tests undoing a penalty draw with a reshuffle
+func TestShouldRestoreAttackChain_WhenUndoingPenaltyDraw(t *testing.T) {
+	// Arrange
+	g := setupHistoryTest()
+	g.PlayCard("player1", card.NewCard(card.Hearts, card.Seven))
+	g.PlayCard("player2", card.NewCard(card.Spades, card.Seven))
+	beforeDraw := mustJSON(t, g)
+	rngBefore := g.State().RNG.Position
+
+	if err := g.DrawCard("player1"); err != nil {
+		t.Fatalf("Failed to draw penalty: %v", err)
+	}
+	afterDraw := mustJSON(t, g)
+	if g.State().RNG.Position == rngBefore {
+		t.Fatal("Expected the penalty draw to reshuffle the discard pile")
+	}
+
+	// Act
+	err := g.Undo()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to undo: %v", err)
+	}
+
+	if !g.IsInAttackChain() || g.GetAttackAmount() != 4 {
+		t.Errorf("Expected attack of 4 to be restored, got %v/%d", g.IsInAttackChain(), g.GetAttackAmount())
+	}
+
+	if g.State().RNG.Position != rngBefore {
+		t.Errorf("Expected RNG position %d to be restored, got %d", rngBefore, g.State().RNG.Position)
+	}
+
+	if !bytes.Equal(beforeDraw, mustJSON(t, g)) {
+		t.Error("Expected undo to restore the state before the draw")
+	}
+
+	// Drawing again from the restored state must give exactly the same result
+	if err := g.DrawCard("player1"); err != nil {
+		t.Fatalf("Failed to draw penalty again: %v", err)
+	}
+
+	if !bytes.Equal(afterDraw, mustJSON(t, g)) {
+		t.Error("Expected the replayed draw to match the original draw")
+	}
+}
+
+// TestShouldReapplyMove_WhenRedoing tests redoing an undone move
+func TestShouldReapplyMove_WhenRedoing(t *testing.T) {
+	// Arrange
+	g := setupHistoryTest()
+	g.DrawCard("player1")
+	afterDraw := mustJSON(t, g)
+	g.Undo()
+
+	// Act
+	err := g.Redo()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to redo: %v", err)
+	}
+
+	if !bytes.Equal(afterDraw, mustJSON(t, g)) {
+		t.Error("Expected redo to restore the state after the draw")
+	}
+
+	history := g.History()
+	if len(history) != 1 || history[0].Type != state.MoveDrawCard {
+		t.Errorf("Expected history to contain the draw, got %v", history)
+	}
+
+	if err := g.Redo(); !errors.Is(err, game.ErrNothingToRedo) {
+		t.Errorf("Expected ErrNothingToRedo, got %v", err)
+	}
+}
+
+// TestShouldClearRedo_WhenApplyingNewMoveAfterUndo tests that a new move discards undone moves
+func TestShouldClearRedo_WhenApplyingNewMoveAfterUndo(t *testing.T) {
+	// Arrange
+	g := setupHistoryTest()
+	g.DrawCard("player1")
+	g.Undo()
+
+	// Act
+	err := g.PlayCard("player1", card.NewCard(card.Hearts, card.Jack))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to play Jack: %v", err)
+	}
+
+	if g.CanRedo() {
+		t.Error("Expected redo to be unavailable after a new move")
+	}
+}
+
+// TestShouldNavigateHistory_WhenGoingToPosition tests jumping to any point in the history
+func TestShouldNavigateHistory_WhenGoingToPosition(t *testing.T) {
+	// Arrange
+	g := setupHistoryTest()
+	snapshots := [][]byte{mustJSON(t, g)}
+	g.PlayCard("player1", card.NewCard(card.Hearts, card.Jack))
+	snapshots = append(snapshots, mustJSON(t, g))
+	g.ChangeSuit("player1", card.Diamonds)
+	snapshots = append(snapshots, mustJSON(t, g))
+	g.PlayCard("player2", card.NewCard(card.Diamonds, card.Four))
+	snapshots = append(snapshots, mustJSON(t, g))
+
+	// Act & Assert
+	for _, position := range []int{0, 2, 1, 3} {
+		if err := g.GoTo(position); err != nil {
+			t.Fatalf("Failed to go to position %d: %v", position, err)
+		}
+
+		if !bytes.Equal(snapshots[position], mustJSON(t, g)) {
+			t.Errorf("Expected state at position %d to match the original", position)
+		}
+
+		if len(g.History()) != position {
+			t.Errorf("Expected %d moves in history, got %d", position, len(g.History()))
+		}
+	}
+
+	if err := g.GoTo(4); err == nil {
+		t.Error("Expected error when going past the end of the history")
+	}
+}
@@ -0,0 +1,283 @@
+package game_test
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/djoufson/check-games-engine/card"
+	"github.com/djoufson/check-games-engine/game"
+)
+
+// playRound applies the first legal move until the round is over
+func playRound(t *testing.T, g *game.Game) {
+	t.Helper()
+	for turn := 0; turn < 1000 && !g.IsGameOver(); turn++ {
+		if err := g.Apply(g.LegalMoves(g.CurrentPlayerID())[0]); err != nil {
+			t.Fatalf("Failed to apply legal move on turn %d: %v", turn, err)
+		}
+	}
+	if !g.IsGameOver() {
+		t.Fatal("Expected the round to finish within the turn limit")
+	}
+}
+
+// newTestMatch creates a three player match
+func newTestMatch(t *testing.T, opts *game.MatchOptions) *game.Match {
+	t.Helper()
+	m, err := game.NewMatch([]string{"player1", "player2", "player3"}, opts)
+	if err != nil {
+		t.Fatalf("Failed to create match: %v", err)
+	}
+	return m
+}
+
+// TestShouldRotateFirstPlayer_WhenStartingNextRound tests the rotation of the starting seat
+func TestShouldRotateFirstPlayer_WhenStartingNextRound(t *testing.T) {
+	// Arrange
+	m := newTestMatch(t, &game.MatchOptions{RandomSeed: 7, MaxRounds: 3})
+
+	// Act & Assert
+	for round, want := range []string{"player1", "player2", "player3"} {
+		if m.Round() != round+1 {
+			t.Fatalf("Expected round %d, got %d", round+1, m.Round())
+		}
+
+		if first := m.Game().CurrentPlayerID(); first != want {
+			t.Errorf("Expected %s to start round %d, got %s", want, round+1, first)
+		}
+
+		playRound(t, m.Game())
+		if err := m.FinishRound(); err != nil {
+			t.Fatalf("Failed to finish round %d: %v", round+1, err)
+		}
+	}
+
+	if !m.IsOver() {
+		t.Error("Expected the match to be over after the round limit")
+	}
+
+	if err := m.FinishRound(); !errors.Is(err, game.ErrMatchOver) {
+		t.Errorf("Expected ErrMatchOver, got %v", err)
+	}
+}
+
+// TestShouldScoreLoserHand_WhenFinishingRound tests cumulative scoring with a custom point table
+func TestShouldScoreLoserHand_WhenFinishingRound(t *testing.T) {
+	// Arrange
+	points := game.DefaultPointTable()
+	points.Ranks[card.King] = 100
+	m := newTestMatch(t, &game.MatchOptions{RandomSeed: 7, TargetScore: 10000, Points: &points})
+	if err := m.FinishRound(); !errors.Is(err, game.ErrRoundInProgress) {
+		t.Fatalf("Expected ErrRoundInProgress, got %v", err)
+	}
+	playRound(t, m.Game())
+	loser := m.Game().GetLoser()
+	hand, _ := m.Game().GetPlayerHand(loser)
+
+	// Act
+	err := m.FinishRound()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to finish round: %v", err)
+	}
+
+	want := points.PointsOf(hand)
+	if got := m.Scores()[loser]; got != want || want == 0 {
+		t.Errorf("Expected %s to score %d, got %d", loser, want, got)
+	}
+
+	rounds := m.Rounds()
+	if len(rounds) != 1 || rounds[0].Points[loser] != want || len(rounds[0].Standings) != 3 {
+		t.Errorf("Expected the round result to be recorded, got %v", rounds)
+	}
+
+	if m.IsOver() || m.Round() != 2 {
+		t.Errorf("Expected the match to continue with round 2, got round %d", m.Round())
+	}
+}
+
+// TestShouldChargeForfeitedHands_WhenFinishingRound tests that quitting scores the hand given up, not a clean round
+func TestShouldChargeForfeitedHands_WhenFinishingRound(t *testing.T) {
+	// Arrange
+	points := game.DefaultPointTable()
+	m := newTestMatch(t, &game.MatchOptions{RandomSeed: 7, MaxRounds: 3})
+	g := m.Game()
+	want := make(map[string]int)
+	for _, id := range []string{"player2", "player3"} {
+		hand, _ := g.GetPlayerHand(id)
+		want[id] = points.PointsOf(hand)
+		if err := g.Forfeit(id); err != nil {
+			t.Fatalf("Failed to forfeit %s: %v", id, err)
+		}
+	}
+
+	// Act
+	err := m.FinishRound()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to finish round: %v", err)
+	}
+
+	scores := m.Scores()
+	if scores["player1"] != 0 {
+		t.Errorf("Expected the player who stayed to score nothing, got %d", scores["player1"])
+	}
+
+	for id, points := range want {
+		if scores[id] != points || points == 0 {
+			t.Errorf("Expected %s to score the %d points of the hand they gave up, got %d", id, points, scores[id])
+		}
+	}
+}
+
+// TestShouldEndMatch_WhenTargetScoreIsReached tests the target score
+func TestShouldEndMatch_WhenTargetScoreIsReached(t *testing.T) {
+	// Arrange
+	m := newTestMatch(t, &game.MatchOptions{RandomSeed: 7, TargetScore: 1})
+	playRound(t, m.Game())
+
+	// Act
+	err := m.FinishRound()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to finish round: %v", err)
+	}
+
+	if !m.IsOver() {
+		t.Fatal("Expected the match to be over once a player reached the target")
+	}
+
+	leaders := m.Leaders()
+	if len(leaders) != 2 {
+		t.Errorf("Expected the two players who went out to share the lead, got %v", leaders)
+	}
+}
+
+// TestShouldResumeMatch_WhenRestoringFromJSON tests that a serialized match continues where it left off
+func TestShouldResumeMatch_WhenRestoringFromJSON(t *testing.T) {
+	// Arrange
+	m := newTestMatch(t, &game.MatchOptions{RandomSeed: 7, MaxRounds: 2})
+	playRound(t, m.Game())
+	m.FinishRound()
+	g := m.Game()
+	g.Apply(g.LegalMoves(g.CurrentPlayerID())[0])
+
+	data, err := m.ToJSON()
+	if err != nil {
+		t.Fatalf("Failed to serialize match: %v", err)
+	}
+
+	// Act
+	restored, err := game.MatchFromJSON(data)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to deserialize match: %v", err)
+	}
+
+	if restored.Round() != 2 || restored.Scores()[restored.Rounds()[0].Standings[2].PlayerID] == 0 {
+		t.Errorf("Expected round 2 with the first round's scores, got round %d and %v", restored.Round(), restored.Scores())
+	}
+
+	playRound(t, m.Game())
+	playRound(t, restored.Game())
+	m.FinishRound()
+	restored.FinishRound()
+
+	original, _ := m.ToJSON()
+	resumed, _ := restored.ToJSON()
+	if !bytes.Equal(original, resumed) {
+		t.Error("Expected the resumed match to finish exactly like the original")
+	}
+}
+
+// TestShouldDealNewHands_WhenStartingNextRoundWithoutSeed tests that the default seed still changes every round
+func TestShouldDealNewHands_WhenStartingNextRoundWithoutSeed(t *testing.T) {
+	// Arrange
+	m := newTestMatch(t, &game.MatchOptions{MaxRounds: 3})
+	firstHand, _ := m.Game().GetPlayerHand("player1")
+	playRound(t, m.Game())
+
+	// Act
+	err := m.FinishRound()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to finish round: %v", err)
+	}
+
+	secondHand, _ := m.Game().GetPlayerHand("player1")
+	if slices.EqualFunc(firstHand, secondHand, card.Card.Matches) {
+		t.Errorf("Expected a new hand in round 2, got %s again", card.FormatHand(secondHand))
+	}
+}
+
+// TestShouldDealDifferentHands_WhenCreatingMatchesWithoutSeed tests that matches without a seed are not all dealt alike
+func TestShouldDealDifferentHands_WhenCreatingMatchesWithoutSeed(t *testing.T) {
+	// Act
+	first := newTestMatch(t, &game.MatchOptions{MaxRounds: 1})
+	second := newTestMatch(t, &game.MatchOptions{MaxRounds: 1})
+
+	// Assert
+	firstHand, _ := first.Game().GetPlayerHand("player1")
+	secondHand, _ := second.Game().GetPlayerHand("player1")
+	if slices.EqualFunc(firstHand, secondHand, card.Card.Matches) {
+		t.Errorf("Expected different hands, got %s twice", card.FormatHand(firstHand))
+	}
+}
+
+// TestShouldDealSameNextRound_WhenResumingMatchWithoutSeed tests that the drawn seed is saved with the match
+func TestShouldDealSameNextRound_WhenResumingMatchWithoutSeed(t *testing.T) {
+	// Arrange
+	m := newTestMatch(t, &game.MatchOptions{MaxRounds: 2})
+	playRound(t, m.Game())
+	data, err := m.ToJSON()
+	if err != nil {
+		t.Fatalf("Failed to serialize match: %v", err)
+	}
+	restored, err := game.MatchFromJSON(data)
+	if err != nil {
+		t.Fatalf("Failed to deserialize match: %v", err)
+	}
+
+	// Act
+	m.FinishRound()
+	restored.FinishRound()
+
+	// Assert
+	original, _ := m.ToJSON()
+	resumed, _ := restored.ToJSON()
+	if !bytes.Equal(original, resumed) {
+		t.Error("Expected the resumed match to deal the same second round")
+	}
+}
+
+// TestShouldRejectMatch_WhenRestoringWithoutCurrentRound tests restoring a match whose round is missing
+func TestShouldRejectMatch_WhenRestoringWithoutCurrentRound(t *testing.T) {
+	// Arrange
+	data := []byte(`{"player_ids":["player1","player2"],"options":{"max_rounds":1},"game":null}`)
+
+	// Act
+	_, err := game.MatchFromJSON(data)
+
+	// Assert
+	if !errors.Is(err, game.ErrNoCurrentRound) {
+		t.Errorf("Expected ErrNoCurrentRound, got %v", err)
+	}
+}
+
+// TestShouldRejectMatch_WhenItCannotEnd tests that a match needs an end condition
+func TestShouldRejectMatch_WhenItCannotEnd(t *testing.T) {
+	// Act
+	_, err := game.NewMatch([]string{"player1", "player2"}, &game.MatchOptions{})
+
+	// Assert
+	if !errors.Is(err, game.ErrNoMatchEnd) {
+		t.Errorf("Expected ErrNoMatchEnd, got %v", err)
+	}
+}
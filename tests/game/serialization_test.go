@@ -1,9 +1,11 @@
 package game_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/djoufson/check-games-engine/game"
+	"github.com/djoufson/check-games-engine/state"
 )
 
 func TestJSONSerialization(t *testing.T) {
@@ -47,3 +49,21 @@ func TestJSONSerialization(t *testing.T) {
 		t.Errorf("Top card mismatch: %v vs %v", topCard1, topCard2)
 	}
 }
+
+func TestJSONSerializationRejectsTamperedState(t *testing.T) {
+	g, _ := game.New([]string{"player1", "player2"}, &game.Options{RandomSeed: 12345})
+
+	// Swap the last discard for another card without updating the top card
+	s := g.State()
+	s.DrawPile.AddToBottom(s.DiscardPile[len(s.DiscardPile)-1])
+	s.DiscardPile = append(s.DiscardPile[:len(s.DiscardPile)-1], s.DrawPile.Cards[0])
+	s.DrawPile.Cards = s.DrawPile.Cards[1:]
+	data, err := s.ToJSON()
+	if err != nil {
+		t.Fatalf("Failed to serialize state: %v", err)
+	}
+
+	if _, err := game.FromJSON(data); !errors.Is(err, state.ErrInvalidState) {
+		t.Errorf("Expected ErrInvalidState, got %v", err)
+	}
+}
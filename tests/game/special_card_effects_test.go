@@ -7,6 +7,7 @@ import (
 	"github.com/djoufson/check-games-engine/deck"
 	"github.com/djoufson/check-games-engine/game"
 	"github.com/djoufson/check-games-engine/player"
+	"github.com/djoufson/check-games-engine/rules"
 	"github.com/djoufson/check-games-engine/state"
 )
 
@@ -69,17 +70,17 @@ func TestSpecialCardEffects(t *testing.T) {
 	}
 
 	// Then change suit
-	err = g.ChangeSuit(player1.ID, card.Hearts)
+	err = g.ChangeSuit(player1.ID, card.Spades)
 	if err != nil {
 		t.Fatalf("Failed to change suit: %v", err)
 	}
 
-	// LastActiveSuit should be Hearts now
-	if g.GetLastActiveSuit() != card.Hearts {
-		t.Errorf("Expected last active suit to be Hearts, got %v", g.GetLastActiveSuit())
+	// LastActiveSuit should be Spades now
+	if g.GetLastActiveSuit() != card.Spades {
+		t.Errorf("Expected last active suit to be Spades, got %v", g.GetLastActiveSuit())
 	}
 
-	// Test wild card (Seven) - should be player2's turn now
+	// Test wild card (Seven) following the declared suit - should be player2's turn now
 	player2SevenCard := card.NewCard(card.Spades, card.Seven)
 	err = g.PlayCard(player2.ID, player2SevenCard)
 	if err != nil {
@@ -106,3 +107,185 @@ func TestSpecialCardEffects(t *testing.T) {
 		t.Errorf("Expected attack amount to be 6, got %d", g.GetAttackAmount())
 	}
 }
+
+func TestValidateMoveHonoursDeclaredSuit(t *testing.T) {
+	// Set up a Jack that player1 will use to declare Diamonds
+	jackCard := card.NewCard(card.Clubs, card.Jack)
+	diamondCard := card.NewCard(card.Diamonds, card.Four)
+	clubCard := card.NewCard(card.Clubs, card.Four)
+
+	player1 := player.New("player1")
+	player1.AddCardsToHand([]card.Card{jackCard, card.NewCard(card.Spades, card.Nine)})
+
+	player2 := player.New("player2")
+	player2.AddCardsToHand([]card.Card{diamondCard, clubCard})
+
+	stateObj := &state.State{
+		Players:         []*player.Player{player1, player2},
+		ActivePlayers:   []string{player1.ID, player2.ID},
+		CurrentPlayerId: player1.ID,
+		Direction:       state.Clockwise,
+		DrawPile:        deck.New(),
+		DiscardPile:     []card.Card{card.NewCard(card.Clubs, card.Queen)},
+		TopCard:         card.NewCard(card.Clubs, card.Queen),
+		LastActiveSuit:  card.Clubs,
+	}
+
+	g := game.FromState(stateObj)
+
+	if err := g.PlayCard(player1.ID, jackCard); err != nil {
+		t.Fatalf("Failed to play Jack: %v", err)
+	}
+	if err := g.ChangeSuit(player1.ID, card.Diamonds); err != nil {
+		t.Fatalf("Failed to change suit: %v", err)
+	}
+
+	if g.GetActiveSuit() != card.Diamonds {
+		t.Errorf("Expected active suit to be Diamonds, got %v", g.GetActiveSuit())
+	}
+
+	if valid, err := g.ValidateMove(player2.ID, diamondCard); !valid || err != nil {
+		t.Errorf("Expected Four of Diamonds to be valid, got %v (%v)", valid, err)
+	}
+
+	if valid, _ := g.ValidateMove(player2.ID, clubCard); valid {
+		t.Error("Expected Four of Clubs to be invalid after Diamonds was declared")
+	}
+
+	playable, err := g.GetPlayableCards(player2.ID)
+	if err != nil {
+		t.Fatalf("Failed to get playable cards: %v", err)
+	}
+
+	if len(playable) != 1 || playable[0] != diamondCard {
+		t.Errorf("Expected only the Four of Diamonds to be playable, got %v", playable)
+	}
+}
+
+func TestTwoIsTransparentForValidation(t *testing.T) {
+	twoCard := card.NewCard(card.Clubs, card.Two)
+	heartsCard := card.NewCard(card.Hearts, card.Eight)
+	clubsCard := card.NewCard(card.Clubs, card.Eight)
+
+	player1 := player.New("player1")
+	player1.AddCardsToHand([]card.Card{twoCard, card.NewCard(card.Spades, card.Nine)})
+
+	player2 := player.New("player2")
+	player2.AddCardsToHand([]card.Card{heartsCard, clubsCard})
+
+	stateObj := &state.State{
+		Players:         []*player.Player{player1, player2},
+		ActivePlayers:   []string{player1.ID, player2.ID},
+		CurrentPlayerId: player1.ID,
+		Direction:       state.Clockwise,
+		DrawPile:        deck.New(),
+		DiscardPile:     []card.Card{card.NewCard(card.Hearts, card.King)},
+		TopCard:         card.NewCard(card.Hearts, card.King),
+		LastActiveSuit:  card.Hearts,
+	}
+
+	g := game.FromState(stateObj)
+
+	if err := g.PlayCard(player1.ID, twoCard); err != nil {
+		t.Fatalf("Failed to play Two: %v", err)
+	}
+
+	if g.GetTopCard() != twoCard {
+		t.Errorf("Expected top card to be the Two, got %v", g.GetTopCard())
+	}
+
+	if effective := g.GetEffectiveTopCard(); effective.Suit != card.Hearts || effective.Rank != card.King {
+		t.Errorf("Expected effective top card to be King of Hearts, got %v", effective)
+	}
+
+	if valid, err := g.ValidateMove(player2.ID, heartsCard); !valid || err != nil {
+		t.Errorf("Expected Eight of Hearts to be valid, got %v (%v)", valid, err)
+	}
+
+	if valid, _ := g.ValidateMove(player2.ID, clubsCard); valid {
+		t.Error("Expected Eight of Clubs to be invalid on a transparent Two")
+	}
+
+	playable, err := g.GetPlayableCards(player2.ID)
+	if err != nil {
+		t.Fatalf("Failed to get playable cards: %v", err)
+	}
+
+	if len(playable) != 1 || playable[0] != heartsCard {
+		t.Errorf("Expected only the Eight of Hearts to be playable, got %v", playable)
+	}
+}
+
+func TestDirectionReversal(t *testing.T) {
+	queenCard := card.NewCard(card.Hearts, card.Queen)
+
+	rs := rules.Default()
+	rs.Effects[card.Queen] = rules.Reverse
+
+	g, err := game.New([]string{"player1", "player2", "player3"}, &game.Options{RandomSeed: 42, Ruleset: &rs})
+	if err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+
+	stateObj := g.State()
+	stateObj.DiscardPile = append(stateObj.DiscardPile, card.NewCard(card.Hearts, card.King))
+	stateObj.TopCard = card.NewCard(card.Hearts, card.King)
+	stateObj.LastActiveSuit = card.Hearts
+	stateObj.FindPlayerByID("player1").AddToHand(queenCard)
+	g = game.FromState(stateObj)
+
+	if g.GetDirection() != state.Clockwise {
+		t.Fatalf("Expected the game to start clockwise, got %v", g.GetDirection())
+	}
+
+	if err := g.PlayCard("player1", queenCard); err != nil {
+		t.Fatalf("Failed to play Queen: %v", err)
+	}
+
+	if g.GetDirection() != state.CounterClockwise {
+		t.Errorf("Expected counter-clockwise direction after the Queen, got %v", g.GetDirection())
+	}
+
+	if g.CurrentPlayerID() != "player3" {
+		t.Errorf("Expected player3 to play next, got %s", g.CurrentPlayerID())
+	}
+}
+
+func TestPlaySeveralCards(t *testing.T) {
+	sevens := []card.Card{card.NewCard(card.Hearts, card.Seven), card.NewCard(card.Spades, card.Seven)}
+
+	rs := rules.Default()
+	rs.MultiPlay = true
+
+	player1 := player.New("player1")
+	player1.AddCardsToHand(append([]card.Card{card.NewCard(card.Clubs, card.Five)}, sevens...))
+
+	player2 := player.New("player2")
+	player2.AddCardsToHand([]card.Card{card.NewCard(card.Diamonds, card.Five)})
+
+	stateObj := &state.State{
+		Players:         []*player.Player{player1, player2},
+		ActivePlayers:   []string{player1.ID, player2.ID},
+		CurrentPlayerId: player1.ID,
+		Direction:       state.Clockwise,
+		DrawPile:        deck.New(),
+		DiscardPile:     []card.Card{card.NewCard(card.Hearts, card.King)},
+		TopCard:         card.NewCard(card.Hearts, card.King),
+		LastActiveSuit:  card.Hearts,
+		Ruleset:         &rs,
+	}
+
+	g := game.FromState(stateObj)
+
+	if err := g.PlayCards(player1.ID, sevens); err != nil {
+		t.Fatalf("Failed to play Sevens: %v", err)
+	}
+
+	if g.GetAttackAmount() != 4 {
+		t.Errorf("Expected attack amount 4, got %d", g.GetAttackAmount())
+	}
+
+	if history := g.History(); len(history) != 1 || history[0].Type != state.MovePlayCards {
+		t.Errorf("Expected the multi-card move in the history, got %v", history)
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/djoufson/check-games-engine/card"
 	"github.com/djoufson/check-games-engine/player"
+	"github.com/djoufson/check-games-engine/rules"
 )
 
 // TestShouldIncreaseHandSize_WhenAddingSingleCard tests adding a single card to a player's hand
@@ -135,3 +136,101 @@ func TestShouldNotDetectCard_WhenCardIsNotInHand(t *testing.T) {
 		t.Error("Expected player not to have King of Hearts")
 	}
 }
+
+// TestShouldRemoveGivenInstance_WhenHoldingCopiesOfACard tests telling apart two copies of the same face
+func TestShouldRemoveGivenInstance_WhenHoldingCopiesOfACard(t *testing.T) {
+	// Arrange
+	p := player.New("player1")
+	first := card.NewCard(card.Hearts, card.Seven)
+	first.ID = 10
+	second := first
+	second.ID = 20
+	p.AddCardsToHand([]card.Card{first, second})
+
+	// Act
+	removed, ok := p.RemoveFromHand(second)
+
+	// Assert
+	if !ok || removed.ID != 20 {
+		t.Fatalf("Expected to remove instance 20, got %+v (%v)", removed, ok)
+	}
+
+	if len(p.Hand) != 1 || p.Hand[0].ID != 10 {
+		t.Errorf("Expected instance 10 to stay in hand, got %v", p.Hand)
+	}
+}
+
+// TestShouldReturnHandInstance_WhenRemovingByFaceValue tests removing a card given without an ID
+func TestShouldReturnHandInstance_WhenRemovingByFaceValue(t *testing.T) {
+	// Arrange
+	p := player.New("player1")
+	held := card.NewCard(card.Clubs, card.King)
+	held.ID = 42
+	p.AddToHand(held)
+
+	// Act
+	removed, ok := p.RemoveFromHand(card.NewCard(card.Clubs, card.King))
+
+	// Assert
+	if !ok || removed != held {
+		t.Errorf("Expected to remove %+v, got %+v (%v)", held, removed, ok)
+	}
+}
+
+// TestShouldFollowRuleset_WhenCheckingForMatchingCard tests that card effects come from the ruleset
+func TestShouldFollowRuleset_WhenCheckingForMatchingCard(t *testing.T) {
+	// Arrange
+	p := player.New("player1")
+	p.AddToHand(card.NewCard(card.Clubs, card.Two))
+	topCard := card.NewCard(card.Hearts, card.Queen)
+
+	rs := rules.Default()
+	delete(rs.Effects, card.Two)
+
+	// Act
+	matchesByDefault := p.HasMatchingCard(topCard, false)
+	matchesWithoutTransparentTwos := p.HasMatchingCardUnder(rs, topCard, false)
+
+	// Assert
+	if !matchesByDefault {
+		t.Error("Expected a transparent 2 to match any card")
+	}
+
+	if matchesWithoutTransparentTwos {
+		t.Error("Expected a plain 2 of Clubs not to match the Queen of Hearts")
+	}
+}
+
+// TestShouldMatchByRankAndColor_WhenWildCardsAreNotIncluded tests that the flag only adds attack-on-attack matches
+func TestShouldMatchByRankAndColor_WhenWildCardsAreNotIncluded(t *testing.T) {
+	// Arrange
+	sameRank := player.New("player1")
+	sameRank.AddToHand(card.NewCard(card.Hearts, card.Seven))
+	sameColor := player.New("player2")
+	sameColor.AddToHand(card.NewRedJoker())
+	otherColor := player.New("player3")
+	otherColor.AddToHand(card.NewBlackJoker())
+
+	// Act
+	rankMatches := sameRank.HasMatchingCard(card.NewCard(card.Spades, card.Seven), false)
+	colorMatches := sameColor.HasMatchingCard(card.NewCard(card.Hearts, card.Seven), false)
+	otherColorMatches := otherColor.HasMatchingCard(card.NewCard(card.Hearts, card.Seven), false)
+	otherColorMatchesAsWild := otherColor.HasMatchingCard(card.NewCard(card.Hearts, card.Seven), true)
+
+	// Assert
+	if !rankMatches {
+		t.Error("Expected the 7 of Hearts to match the 7 of Spades by rank")
+	}
+
+	if !colorMatches {
+		t.Error("Expected the red joker to match the 7 of Hearts by color")
+	}
+
+	if otherColorMatches {
+		t.Error("Expected the black joker not to match the 7 of Hearts without wild cards")
+	}
+
+	if !otherColorMatchesAsWild {
+		t.Error("Expected the black joker to match the 7 of Hearts as a wild card")
+	}
+}
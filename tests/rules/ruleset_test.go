@@ -0,0 +1,122 @@
+package rules_test
+
+import (
+	"testing"
+
+	"github.com/djoufson/check-games-engine/card"
+	"github.com/djoufson/check-games-engine/rules"
+)
+
+// TestShouldMatchCardMethods_WhenUsingDefaultRuleset tests that the default preset keeps the standard card semantics
+func TestShouldMatchCardMethods_WhenUsingDefaultRuleset(t *testing.T) {
+	// Arrange
+	rs := rules.Default()
+	cards := []card.Card{card.NewRedJoker(), card.NewBlackJoker()}
+	for _, suit := range []card.Suit{card.Spades, card.Hearts, card.Diamonds, card.Clubs} {
+		for _, rank := range []card.Rank{
+			card.Ace, card.Two, card.Three, card.Four, card.Five, card.Six, card.Seven,
+			card.Eight, card.Nine, card.Ten, card.Jack, card.Queen, card.King,
+		} {
+			cards = append(cards, card.NewCard(suit, rank))
+		}
+	}
+
+	// Act & Assert
+	for _, c := range cards {
+		if rs.IsSkip(c) != c.IsSkip() {
+			t.Errorf("Skip mismatch for %v", c)
+		}
+		if rs.IsAttack(c) != c.IsWildCard() {
+			t.Errorf("Attack mismatch for %v", c)
+		}
+		if rs.IsSuitChanger(c) != c.IsSuitChanger() {
+			t.Errorf("Suit changer mismatch for %v", c)
+		}
+		if rs.IsTransparent(c) != c.IsTransparent() {
+			t.Errorf("Transparent mismatch for %v", c)
+		}
+		if rs.DrawPenalty(c) != c.GetDrawPenalty() {
+			t.Errorf("Penalty mismatch for %v: %d vs %d", c, rs.DrawPenalty(c), c.GetDrawPenalty())
+		}
+	}
+}
+
+// TestShouldAssignEffects_WhenUsingCustomRuleset tests reassigning effects to other ranks
+func TestShouldAssignEffects_WhenUsingCustomRuleset(t *testing.T) {
+	// Arrange
+	rs := rules.Default()
+	rs.Effects[card.Eight] = rules.Skip
+	delete(rs.Effects, card.Ace)
+	rs.Penalties[card.Seven] = 3
+
+	eight := card.NewCard(card.Clubs, card.Eight)
+	ace := card.NewCard(card.Clubs, card.Ace)
+	seven := card.NewCard(card.Clubs, card.Seven)
+
+	// Act & Assert
+	if !rs.IsSkip(eight) {
+		t.Error("Expected Eight to skip")
+	}
+
+	if rs.IsSpecial(ace) {
+		t.Error("Expected Ace to have no effect")
+	}
+
+	if rs.DrawPenalty(seven) != 3 {
+		t.Errorf("Expected Seven penalty to be 3, got %d", rs.DrawPenalty(seven))
+	}
+}
+
+// TestShouldNotShareMaps_WhenCloningRuleset tests that clones are independent
+func TestShouldNotShareMaps_WhenCloningRuleset(t *testing.T) {
+	// Arrange
+	rs := rules.Default()
+
+	// Act
+	clone := rs.Clone()
+	clone.Effects[card.King] = rules.Skip
+
+	// Assert
+	if rs.IsSkip(card.NewCard(card.Hearts, card.King)) {
+		t.Error("Expected original ruleset to be unchanged")
+	}
+}
+
+// TestShouldRejectRuleset_WhenAttackRankHasNoPenalty tests ruleset validation
+func TestShouldRejectRuleset_WhenAttackRankHasNoPenalty(t *testing.T) {
+	// Arrange
+	rs := rules.Default()
+	rs.Effects[card.King] = rules.Attack
+
+	// Act
+	err := rs.Validate()
+
+	// Assert
+	if err == nil {
+		t.Error("Expected error for an attacking rank without a penalty")
+	}
+
+	if err := rules.Default().Validate(); err != nil {
+		t.Errorf("Expected default ruleset to be valid, got %v", err)
+	}
+}
+
+// TestShouldRejectRuleset_WhenFinishPenaltyIsMissing tests validation of the special finish rule
+func TestShouldRejectRuleset_WhenFinishPenaltyIsMissing(t *testing.T) {
+	// Arrange
+	rs := rules.Default()
+	rs.SpecialFinish = rules.FinishPenalty
+
+	// Act
+	err := rs.Validate()
+
+	// Assert
+	if err == nil {
+		t.Error("Expected error for a finish penalty rule without a penalty")
+	}
+
+	rs.FinishPenalty = 2
+	if err := rs.Validate(); err != nil {
+		t.Errorf("Expected ruleset with a finish penalty to be valid, got %v", err)
+	}
+}
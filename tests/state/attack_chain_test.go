@@ -14,8 +14,9 @@ func setupAttackChainTest() (*state.State, *player.Player, *player.Player) {
 	// Create players with specific cards
 	player1 := player.New("player1")
 	player1.AddCardsToHand([]card.Card{
-		card.NewCard(card.Hearts, card.Seven), // Wild (+2)
-		card.NewRedJoker(),                    // Wild (+4)
+		card.NewCard(card.Hearts, card.Seven),   // Wild (+2)
+		card.NewRedJoker(),                      // Wild (+4)
+		card.NewCard(card.Diamonds, card.Three), // Regular card, so playing the Joker does not end the game
 	})
 
 	player2 := player.New("player2")
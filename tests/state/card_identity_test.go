@@ -0,0 +1,114 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/djoufson/check-games-engine/card"
+	"github.com/djoufson/check-games-engine/state"
+)
+
+// TestShouldKeepInstance_WhenPlayingByFaceValue tests that the played instance lands on the discard pile
+func TestShouldKeepInstance_WhenPlayingByFaceValue(t *testing.T) {
+	// Arrange
+	gameState := setupValidStateTest(t)
+	move := gameState.LegalMoves("player1")[0]
+	if move.Type != state.MovePlayCard {
+		t.Fatalf("Expected a card to be playable, got %s", move.Type)
+	}
+	held := *move.Card
+
+	// Act
+	err := gameState.PlayCard("player1", held.Face())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to play card: %v", err)
+	}
+
+	if gameState.TopCard != held || held.ID == 0 {
+		t.Errorf("Expected instance %+v on top, got %+v", held, gameState.TopCard)
+	}
+
+	for _, e := range gameState.DrainEvents() {
+		if e.Type == state.EventCardPlayed && e.Cards[0] != held {
+			t.Errorf("Expected the event to carry instance %d, got %+v", held.ID, e.Cards[0])
+		}
+	}
+}
+
+// TestShouldPlayGivenInstance_WhenHoldingCopiesOfACard tests playing one of two identical faces
+func TestShouldPlayGivenInstance_WhenHoldingCopiesOfACard(t *testing.T) {
+	// Arrange
+	gameState, player1, _ := setupAttackChainTest()
+	second := card.NewCard(card.Hearts, card.Seven)
+	second.ID = 99
+	player1.Hand[0].ID = 98
+	player1.AddToHand(second)
+
+	// Act
+	err := gameState.PlayCard("player1", second)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to play card: %v", err)
+	}
+
+	if gameState.TopCard.ID != 99 {
+		t.Errorf("Expected instance 99 on top, got %+v", gameState.TopCard)
+	}
+
+	if !player1.HasCard(player1.Hand[0]) || player1.Hand[0].ID != 98 {
+		t.Errorf("Expected instance 98 to stay in hand, got %v", player1.Hand)
+	}
+}
+
+// TestShouldKeepCardIDs_WhenCloningAndSerializing tests that IDs survive Clone and JSON
+func TestShouldKeepCardIDs_WhenCloningAndSerializing(t *testing.T) {
+	// Arrange
+	gameState := setupValidStateTest(t)
+	data, err := gameState.ToJSON()
+	if err != nil {
+		t.Fatalf("Failed to serialize state: %v", err)
+	}
+
+	// Act
+	clone := gameState.Clone()
+	restored, err := state.FromJSON(data)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to deserialize state: %v", err)
+	}
+
+	for _, s := range []*state.State{clone, restored} {
+		for i, c := range s.DrawPile.Cards {
+			if c.ID == 0 || c != gameState.DrawPile.Cards[i] {
+				t.Fatalf("Expected draw pile card %+v, got %+v", gameState.DrawPile.Cards[i], c)
+			}
+		}
+		if s.Players[0].Hand[0] != gameState.Players[0].Hand[0] {
+			t.Errorf("Expected hand card %+v, got %+v", gameState.Players[0].Hand[0], s.Players[0].Hand[0])
+		}
+	}
+}
+
+// TestShouldPlayWholeGame_WhenShoeHasDuplicateFaces tests play and validation with two decks
+func TestShouldPlayWholeGame_WhenShoeHasDuplicateFaces(t *testing.T) {
+	// Arrange
+	playerIDs := []string{"player1", "player2", "player3", "player4", "player5", "player6"}
+	gameState, err := state.New(playerIDs, &state.GameOptions{RandomSeed: 3})
+	if err != nil {
+		t.Fatalf("Failed to create new game: %v", err)
+	}
+
+	// Act & Assert
+	for turn := 0; turn < 500 && !gameState.IsGameOver(); turn++ {
+		moves := gameState.LegalMoves(gameState.CurrentPlayerID())
+		if err := gameState.Apply(moves[turn%len(moves)]); err != nil {
+			t.Fatalf("Failed to apply legal move on turn %d: %v", turn, err)
+		}
+		if err := gameState.Validate(); err != nil {
+			t.Fatalf("Expected a valid state after turn %d, got %v", turn, err)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package state_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/djoufson/check-games-engine/card"
+	"github.com/djoufson/check-games-engine/deck"
+	"github.com/djoufson/check-games-engine/player"
+	"github.com/djoufson/check-games-engine/state"
+)
+
+// setupCheckCallTest creates a state where player1 is about to go down to one card
+func setupCheckCallTest() *state.State {
+	player1 := player.New("player1")
+	player1.AddCardsToHand([]card.Card{
+		card.NewCard(card.Hearts, card.Five),
+		card.NewCard(card.Clubs, card.Nine),
+	})
+
+	player2 := player.New("player2")
+	player2.AddCardsToHand([]card.Card{
+		card.NewCard(card.Hearts, card.Six),
+		card.NewCard(card.Diamonds, card.King),
+	})
+
+	player3 := player.New("player3")
+	player3.AddCardsToHand([]card.Card{
+		card.NewCard(card.Spades, card.Four),
+		card.NewCard(card.Diamonds, card.Four),
+	})
+
+	return &state.State{
+		Players:         []*player.Player{player1, player2, player3},
+		ActivePlayers:   []string{player1.ID, player2.ID, player3.ID},
+		CurrentPlayerId: player1.ID,
+		Direction:       state.Clockwise,
+		DrawPile:        deck.New(),
+		DiscardPile:     []card.Card{card.NewCard(card.Hearts, card.Queen)},
+		TopCard:         card.NewCard(card.Hearts, card.Queen),
+		LastActiveSuit:  card.Hearts,
+	}
+}
+
+// TestShouldDrawPenalty_WhenChallengedForNotAnnouncing tests a successful challenge
+func TestShouldDrawPenalty_WhenChallengedForNotAnnouncing(t *testing.T) {
+	// Arrange
+	gameState := setupCheckCallTest()
+	gameState.PlayCard("player1", card.NewCard(card.Hearts, card.Five))
+
+	// Act
+	err := gameState.ChallengeCheck("player3", "player1")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to challenge: %v", err)
+	}
+
+	if size := gameState.FindPlayerByID("player1").HandSize(); size != 3 {
+		t.Errorf("Expected player1 to draw 2 penalty cards, got %d cards", size)
+	}
+
+	if gameState.CurrentPlayerID() != "player2" {
+		t.Errorf("Expected the challenge not to change the turn, got %s", gameState.CurrentPlayerID())
+	}
+
+	if err := gameState.ChallengeCheck("player2", "player1"); !errors.Is(err, state.ErrNothingToChallenge) {
+		t.Errorf("Expected a second challenge to be rejected, got %v", err)
+	}
+}
+
+// TestShouldKeepPlayerChallengeable_WhenAnnouncingLate tests that announcing after reaching one card does not escape a challenge
+func TestShouldKeepPlayerChallengeable_WhenAnnouncingLate(t *testing.T) {
+	// Arrange
+	gameState := setupCheckCallTest()
+	gameState.PlayCard("player1", card.NewCard(card.Hearts, card.Five))
+
+	// Act
+	announceErr := gameState.AnnounceCheck("player1")
+	challengeErr := gameState.ChallengeCheck("player2", "player1")
+
+	// Assert
+	if !errors.Is(announceErr, state.ErrCannotAnnounce) {
+		t.Errorf("Expected the late announcement to be rejected, got %v", announceErr)
+	}
+
+	if gameState.HasAnnouncedCheck("player1") {
+		t.Error("Expected player1 not to have announced Check")
+	}
+
+	if challengeErr != nil {
+		t.Fatalf("Expected the challenge to succeed, got %v", challengeErr)
+	}
+
+	if size := gameState.FindPlayerByID("player1").HandSize(); size != 3 {
+		t.Errorf("Expected player1 to draw 2 penalty cards, got %d cards", size)
+	}
+}
+
+// TestShouldNotExpose_WhenAnnouncingBeforePlaying tests announcing on the turn with two cards left
+func TestShouldNotExpose_WhenAnnouncingBeforePlaying(t *testing.T) {
+	// Arrange
+	gameState := setupCheckCallTest()
+
+	// Act
+	announceErr := gameState.AnnounceCheck("player1")
+	playErr := gameState.PlayCard("player1", card.NewCard(card.Hearts, card.Five))
+
+	// Assert
+	if announceErr != nil || playErr != nil {
+		t.Fatalf("Failed to announce and play: %v, %v", announceErr, playErr)
+	}
+
+	if gameState.CheckExposed != "" {
+		t.Errorf("Expected no player to be exposed, got %s", gameState.CheckExposed)
+	}
+
+	if err := gameState.AnnounceCheck("player3"); !errors.Is(err, state.ErrCannotAnnounce) {
+		t.Errorf("Expected player3 not to be able to announce out of turn with two cards, got %v", err)
+	}
+}
+
+// TestShouldCloseChallengeWindow_WhenNextPlayerMoves tests that the challenge must come before the next move
+func TestShouldCloseChallengeWindow_WhenNextPlayerMoves(t *testing.T) {
+	// Arrange
+	gameState := setupCheckCallTest()
+	gameState.PlayCard("player1", card.NewCard(card.Hearts, card.Five))
+	gameState.PlayCard("player2", card.NewCard(card.Hearts, card.Six))
+
+	// Act
+	err := gameState.ChallengeCheck("player3", "player1")
+
+	// Assert
+	if !errors.Is(err, state.ErrNothingToChallenge) {
+		t.Errorf("Expected ErrNothingToChallenge, got %v", err)
+	}
+
+	if size := gameState.FindPlayerByID("player1").HandSize(); size != 1 {
+		t.Errorf("Expected player1 to keep one card, got %d", size)
+	}
+}
+
+// TestShouldRejectChallenge_WhenRuleIsDisabled tests a ruleset without check penalty
+func TestShouldRejectChallenge_WhenRuleIsDisabled(t *testing.T) {
+	// Arrange
+	gameState := setupCheckCallTest()
+	rs := gameState.Rules()
+	rs.CheckPenalty = 0
+	gameState.Ruleset = &rs
+	gameState.PlayCard("player1", card.NewCard(card.Hearts, card.Five))
+
+	// Act
+	err := gameState.ChallengeCheck("player2", "player1")
+
+	// Assert
+	if !errors.Is(err, state.ErrChallengesDisabled) {
+		t.Errorf("Expected ErrChallengesDisabled, got %v", err)
+	}
+}
+
+// TestShouldKeepCheckCall_WhenRoundTrippingJSON tests that the announcement state is serialized
+func TestShouldKeepCheckCall_WhenRoundTrippingJSON(t *testing.T) {
+	// Arrange
+	gameState := setupCheckCallTest()
+	gameState.PlayCard("player1", card.NewCard(card.Hearts, card.Five))
+	completeDrawPile(gameState)
+	data, err := gameState.ToJSON()
+	if err != nil {
+		t.Fatalf("Failed to serialize state: %v", err)
+	}
+
+	// Act
+	restored, err := state.FromJSON(data)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to deserialize state: %v", err)
+	}
+
+	if restored.CheckExposed != "player1" {
+		t.Errorf("Expected player1 to still be exposed, got %q", restored.CheckExposed)
+	}
+
+	if err := restored.ChallengeCheck("player2", "player1"); err != nil {
+		t.Errorf("Expected the challenge to succeed after the round trip, got %v", err)
+	}
+}
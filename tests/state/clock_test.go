@@ -0,0 +1,230 @@
+package state_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/djoufson/check-games-engine/card"
+	"github.com/djoufson/check-games-engine/state"
+)
+
+// fakeClock is a clock that only moves when told to
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+// setupTimedGame creates a two-player game with the given time control
+func setupTimedGame(t *testing.T, tc state.TimeControl) (*state.State, *fakeClock) {
+	t.Helper()
+
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	gameState, err := state.New([]string{"player1", "player2"}, &state.GameOptions{
+		RandomSeed:  42,
+		TimeControl: &tc,
+		Clock:       clock,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create timed game: %v", err)
+	}
+
+	return gameState, clock
+}
+
+// TestShouldDrawCard_WhenTimeRunsOut tests the default timeout action
+func TestShouldDrawCard_WhenTimeRunsOut(t *testing.T) {
+	// Arrange
+	gameState, clock := setupTimedGame(t, state.TimeControl{PerMove: 30 * time.Second})
+	handSize := gameState.FindPlayerByID("player1").HandSize()
+	clock.Advance(31 * time.Second)
+
+	// Act
+	err := gameState.Apply(state.NewTimeoutMove("player1"))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to apply timeout: %v", err)
+	}
+
+	if got := gameState.FindPlayerByID("player1").HandSize(); got != handSize+1 {
+		t.Errorf("Expected player1 to hold %d cards, got %d", handSize+1, got)
+	}
+
+	if gameState.CurrentPlayerID() != "player2" {
+		t.Errorf("Expected player2's turn, got %s", gameState.CurrentPlayerID())
+	}
+
+	events := gameState.DrainEvents()
+	if len(events) == 0 || events[0].Type != state.EventTimedOut {
+		t.Errorf("Expected a %s event first, got %v", state.EventTimedOut, events)
+	}
+}
+
+// TestShouldRejectTimeout_WhenTimeIsNotUp tests that a timeout is only accepted after the deadline
+func TestShouldRejectTimeout_WhenTimeIsNotUp(t *testing.T) {
+	// Arrange
+	gameState, clock := setupTimedGame(t, state.TimeControl{PerMove: 30 * time.Second})
+	clock.Advance(10 * time.Second)
+
+	// Act
+	err := gameState.Timeout("player1")
+
+	// Assert
+	if !errors.Is(err, state.ErrTimeNotUp) {
+		t.Errorf("Expected ErrTimeNotUp, got %v", err)
+	}
+
+	deadline, ok := gameState.TurnDeadline()
+	if !ok || !deadline.Equal(clock.now.Add(20*time.Second)) {
+		t.Errorf("Expected the deadline 20s from now, got %v (%v)", deadline, ok)
+	}
+}
+
+// TestShouldChargeBank_WhenTurnEnds tests that the turn's time leaves the bank and the increment is added
+func TestShouldChargeBank_WhenTurnEnds(t *testing.T) {
+	// Arrange
+	gameState, clock := setupTimedGame(t, state.TimeControl{Bank: time.Minute, Increment: 5 * time.Second})
+	clock.Advance(20 * time.Second)
+
+	// Act
+	err := gameState.DrawCard("player1")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to draw: %v", err)
+	}
+
+	if left, ok := gameState.TimeLeft("player1"); !ok || left != 45*time.Second {
+		t.Errorf("Expected 45s left for player1, got %v (%v)", left, ok)
+	}
+
+	clock.Advance(10 * time.Second)
+	if left, _ := gameState.TimeLeft("player2"); left != 50*time.Second {
+		t.Errorf("Expected player2's running clock to show 50s, got %v", left)
+	}
+}
+
+// TestShouldUseBank_WhenItHoldsLessThanAMove tests that an almost empty bank cuts the turn short
+func TestShouldUseBank_WhenItHoldsLessThanAMove(t *testing.T) {
+	// Arrange
+	gameState, clock := setupTimedGame(t, state.TimeControl{PerMove: 30 * time.Second, Bank: 10 * time.Second})
+
+	// Act
+	clock.Advance(10 * time.Second)
+
+	// Assert
+	if !gameState.HasTimedOut() {
+		t.Error("Expected player1 to run out of time with an empty bank")
+	}
+}
+
+// TestShouldForfeit_WhenTimeoutActionIsForfeit tests that a player who runs out of time can lose the game
+func TestShouldForfeit_WhenTimeoutActionIsForfeit(t *testing.T) {
+	// Arrange
+	gameState, clock := setupTimedGame(t, state.TimeControl{PerMove: 30 * time.Second, OnTimeout: state.TimeoutForfeit})
+	clock.Advance(time.Minute)
+
+	// Act
+	err := gameState.Timeout("player1")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to apply timeout: %v", err)
+	}
+
+	if !gameState.IsGameOver() {
+		t.Fatal("Expected the game to be over")
+	}
+
+	if loser := gameState.GetLoser(); loser != "player1" {
+		t.Errorf("Expected player1 to lose, got %s", loser)
+	}
+
+	if winners := gameState.GetWinner(); len(winners) != 1 || winners[0] != "player2" {
+		t.Errorf("Expected player2 to win, got %v", winners)
+	}
+}
+
+// TestShouldAcceptAttack_WhenPassingOnTimeout tests that passing still draws the attack penalty
+func TestShouldAcceptAttack_WhenPassingOnTimeout(t *testing.T) {
+	// Arrange
+	gameState, player1, _ := setupAttackChainTest()
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	gameState.SetClock(clock)
+	gameState.Clocks = &state.Clocks{
+		Control:   state.TimeControl{PerMove: 30 * time.Second, OnTimeout: state.TimeoutPass},
+		TurnStart: clock.Now(),
+	}
+
+	if err := gameState.PlayCard("player1", card.NewCard(card.Hearts, card.Seven)); err != nil {
+		t.Fatalf("Failed to attack: %v", err)
+	}
+	if err := gameState.PlayCard("player2", card.NewCard(card.Spades, card.Seven)); err != nil {
+		t.Fatalf("Failed to escalate: %v", err)
+	}
+	handSize := player1.HandSize()
+	clock.Advance(time.Minute)
+
+	// Act
+	err := gameState.Timeout("player1")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to apply timeout: %v", err)
+	}
+
+	if got := player1.HandSize(); got != handSize+4 {
+		t.Errorf("Expected player1 to draw the 4 card penalty, got %d cards", got-handSize)
+	}
+
+	if gameState.InAttackChain {
+		t.Error("Expected the attack chain to be resolved")
+	}
+}
+
+// TestShouldKeepClocks_WhenSerialized tests that the clocks survive a JSON round trip
+func TestShouldKeepClocks_WhenSerialized(t *testing.T) {
+	// Arrange
+	gameState, clock := setupTimedGame(t, state.TimeControl{PerMove: 30 * time.Second, Bank: time.Minute})
+	clock.Advance(5 * time.Second)
+	if err := gameState.DrawCard("player1"); err != nil {
+		t.Fatalf("Failed to draw: %v", err)
+	}
+
+	// Act
+	data, err := gameState.ToJSON()
+	if err != nil {
+		t.Fatalf("Failed to serialize: %v", err)
+	}
+	restored, err := state.FromJSON(data)
+	if err != nil {
+		t.Fatalf("Failed to deserialize: %v", err)
+	}
+	restored.SetClock(clock)
+
+	// Assert
+	if restored.Clocks == nil {
+		t.Fatal("Expected the clocks to be restored")
+	}
+
+	if restored.Clocks.Control != gameState.Clocks.Control {
+		t.Errorf("Expected time control %+v, got %+v", gameState.Clocks.Control, restored.Clocks.Control)
+	}
+
+	want, _ := gameState.TurnDeadline()
+	if got, ok := restored.TurnDeadline(); !ok || !got.Equal(want) {
+		t.Errorf("Expected deadline %v, got %v", want, got)
+	}
+
+	if left, _ := restored.TimeLeft("player1"); left != 55*time.Second {
+		t.Errorf("Expected 55s left for player1, got %v", left)
+	}
+}
@@ -0,0 +1,177 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/djoufson/check-games-engine/card"
+	"github.com/djoufson/check-games-engine/deck"
+	"github.com/djoufson/check-games-engine/player"
+	"github.com/djoufson/check-games-engine/state"
+)
+
+// setupDeclaredSuitTest creates a game state where player1 has played a Jack and declared Spades
+func setupDeclaredSuitTest(t *testing.T) (*state.State, *player.Player, *player.Player) {
+	player1 := player.New("player1")
+	player1.AddCardsToHand([]card.Card{
+		card.NewCard(card.Hearts, card.Jack),
+		card.NewCard(card.Hearts, card.Four),
+	})
+
+	player2 := player.New("player2")
+	player2.AddCardsToHand([]card.Card{
+		card.NewCard(card.Spades, card.Five),  // Follows the declared suit
+		card.NewCard(card.Hearts, card.Five),  // Follows the Jack's suit only
+		card.NewCard(card.Diamonds, card.Six), // Matches nothing
+		card.NewBlackJoker(),                  // Same color as Spades
+		card.NewRedJoker(),                    // Same color as the Jack only
+	})
+
+	topCard := card.NewCard(card.Hearts, card.Queen)
+	gameState := &state.State{
+		Players:         []*player.Player{player1, player2},
+		ActivePlayers:   []string{player1.ID, player2.ID},
+		CurrentPlayerId: player1.ID,
+		Direction:       state.Clockwise,
+		DrawPile:        deck.New(),
+		DiscardPile:     []card.Card{topCard},
+		TopCard:         topCard,
+		LastActiveSuit:  card.Hearts,
+	}
+
+	if err := gameState.PlayCard("player1", card.NewCard(card.Hearts, card.Jack)); err != nil {
+		t.Fatalf("Failed to play Jack: %v", err)
+	}
+	if err := gameState.ChangeSuit("player1", card.Spades); err != nil {
+		t.Fatalf("Failed to change suit: %v", err)
+	}
+
+	return gameState, player1, player2
+}
+
+// TestShouldAcceptCard_WhenFollowingDeclaredSuit tests that a card of the declared suit can be played
+func TestShouldAcceptCard_WhenFollowingDeclaredSuit(t *testing.T) {
+	// Arrange
+	gameState, _, _ := setupDeclaredSuitTest(t)
+
+	// Act
+	err := gameState.PlayCard("player2", card.NewCard(card.Spades, card.Five))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to play card following declared suit: %v", err)
+	}
+}
+
+// TestShouldRejectCard_WhenFollowingJackSuitInsteadOfDeclaredSuit tests that the Jack's own suit is overridden
+func TestShouldRejectCard_WhenFollowingJackSuitInsteadOfDeclaredSuit(t *testing.T) {
+	// Arrange
+	gameState, _, _ := setupDeclaredSuitTest(t)
+
+	// Act
+	err := gameState.PlayCard("player2", card.NewCard(card.Hearts, card.Five))
+
+	// Assert
+	if err == nil {
+		t.Error("Expected error when playing the Jack's suit after another suit was declared")
+	}
+}
+
+// TestShouldMatchJokerByDeclaredSuitColor_WhenSuitWasDeclared tests joker color matching against the declared suit
+func TestShouldMatchJokerByDeclaredSuitColor_WhenSuitWasDeclared(t *testing.T) {
+	// Arrange
+	gameState, _, _ := setupDeclaredSuitTest(t)
+
+	// Act
+	redErr := gameState.Clone().PlayCard("player2", card.NewRedJoker())
+	blackErr := gameState.Clone().PlayCard("player2", card.NewBlackJoker())
+
+	// Assert
+	if redErr == nil {
+		t.Error("Expected red joker to be rejected after Spades was declared")
+	}
+
+	if blackErr != nil {
+		t.Errorf("Expected black joker to be accepted after Spades was declared, got %v", blackErr)
+	}
+}
+
+// TestShouldOnlyListDeclaredSuitCards_WhenGettingPlayableCards tests playable cards honour the declared suit
+func TestShouldOnlyListDeclaredSuitCards_WhenGettingPlayableCards(t *testing.T) {
+	// Arrange
+	gameState, _, player2 := setupDeclaredSuitTest(t)
+
+	// Act
+	playable := player2.PlayableCardsUnder(gameState.Rules(), gameState.TopCard, gameState.ActiveSuit(), gameState.InAttackChain)
+
+	// Assert
+	if len(playable) != 2 {
+		t.Fatalf("Expected 2 playable cards, got %v", playable)
+	}
+
+	for _, c := range playable {
+		if c.Suit != card.Spades && !(c.IsJoker() && c.Color == card.Black) {
+			t.Errorf("Expected only Spades or the black joker to be playable, got %v", c)
+		}
+	}
+}
+
+// TestShouldClearDeclaredSuit_WhenNextCardIsPlayed tests that the declared suit only applies to the next card
+func TestShouldClearDeclaredSuit_WhenNextCardIsPlayed(t *testing.T) {
+	// Arrange
+	gameState, _, _ := setupDeclaredSuitTest(t)
+
+	// Act
+	err := gameState.PlayCard("player2", card.NewCard(card.Spades, card.Five))
+	if err != nil {
+		t.Fatalf("Failed to play card following declared suit: %v", err)
+	}
+
+	// Assert
+	if gameState.ActiveSuit() != card.Spades || gameState.LastActiveSuit != card.Spades {
+		t.Errorf("Expected active suit to follow the Five of Spades, got %v", gameState.ActiveSuit())
+	}
+
+	// Player1 can now match the Five by rank even though it is not a Spade
+	player1 := gameState.FindPlayerByID("player1")
+	player1.AddToHand(card.NewCard(card.Clubs, card.Five))
+	err = gameState.PlayCard("player1", card.NewCard(card.Clubs, card.Five))
+	if err != nil {
+		t.Errorf("Expected rank match to be accepted once the declared suit was cleared, got %v", err)
+	}
+}
+
+// TestShouldKeepLastActiveSuit_WhenJokerIsPlayed tests that a Joker does not become the last active suit
+func TestShouldKeepLastActiveSuit_WhenJokerIsPlayed(t *testing.T) {
+	// Arrange
+	player1 := player.New("player1")
+	player1.AddCardsToHand([]card.Card{
+		card.NewRedJoker(),
+		card.NewCard(card.Hearts, card.Four),
+	})
+	player2 := player.New("player2")
+	player2.AddToHand(card.NewCard(card.Clubs, card.Five))
+
+	topCard := card.NewCard(card.Hearts, card.Queen)
+	gameState := &state.State{
+		Players:         []*player.Player{player1, player2},
+		ActivePlayers:   []string{player1.ID, player2.ID},
+		CurrentPlayerId: player1.ID,
+		Direction:       state.Clockwise,
+		DrawPile:        deck.New(),
+		DiscardPile:     []card.Card{topCard},
+		TopCard:         topCard,
+		LastActiveSuit:  card.Hearts,
+	}
+
+	// Act
+	err := gameState.PlayCard("player1", card.NewRedJoker())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to play Joker: %v", err)
+	}
+
+	if gameState.LastActiveSuit != card.Hearts {
+		t.Errorf("Expected the last active suit to stay Hearts, got %s", gameState.LastActiveSuit)
+	}
+}
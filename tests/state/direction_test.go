@@ -0,0 +1,167 @@
+package state_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/djoufson/check-games-engine/card"
+	"github.com/djoufson/check-games-engine/deck"
+	"github.com/djoufson/check-games-engine/player"
+	"github.com/djoufson/check-games-engine/rules"
+	"github.com/djoufson/check-games-engine/state"
+)
+
+// setupReversalTest creates a state where Eights reverse the direction of play
+func setupReversalTest(playerIDs ...string) *state.State {
+	rs := rules.Default()
+	rs.Effects[card.Eight] = rules.Reverse
+
+	players := make([]*player.Player, 0, len(playerIDs))
+	for _, id := range playerIDs {
+		p := player.New(id)
+		p.AddCardsToHand([]card.Card{
+			card.NewCard(card.Hearts, card.Eight),
+			card.NewCard(card.Hearts, card.Ace),
+			card.NewCard(card.Hearts, card.Five),
+		})
+		players = append(players, p)
+	}
+
+	return &state.State{
+		Players:         players,
+		ActivePlayers:   playerIDs,
+		CurrentPlayerId: playerIDs[0],
+		Direction:       state.Clockwise,
+		DrawPile:        deck.New(),
+		DiscardPile:     []card.Card{card.NewCard(card.Hearts, card.Queen)},
+		TopCard:         card.NewCard(card.Hearts, card.Queen),
+		LastActiveSuit:  card.Hearts,
+		Ruleset:         &rs,
+	}
+}
+
+// TestShouldReverseDirection_WhenPlayingReversalCard tests reversing with more than two players
+func TestShouldReverseDirection_WhenPlayingReversalCard(t *testing.T) {
+	// Arrange
+	gameState := setupReversalTest("player1", "player2", "player3")
+
+	// Act
+	err := gameState.PlayCard("player1", card.NewCard(card.Hearts, card.Eight))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to play Eight: %v", err)
+	}
+
+	if gameState.Direction != state.CounterClockwise {
+		t.Errorf("Expected counter-clockwise direction, got %v", gameState.Direction)
+	}
+
+	if gameState.CurrentPlayerID() != "player3" {
+		t.Errorf("Expected player3 to play next, got %s", gameState.CurrentPlayerID())
+	}
+
+	events := gameState.DrainEvents()
+	found := false
+	for _, e := range events {
+		if e.Type == state.EventDirectionChanged && e.Direction != nil && *e.Direction == state.CounterClockwise {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected a direction change event, got %v", events)
+	}
+}
+
+// TestShouldPlayAgain_WhenReversingWithTwoPlayers tests that a reversal acts like a skip with two players
+func TestShouldPlayAgain_WhenReversingWithTwoPlayers(t *testing.T) {
+	// Arrange
+	gameState := setupReversalTest("player1", "player2")
+
+	// Act
+	err := gameState.PlayCard("player1", card.NewCard(card.Hearts, card.Eight))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to play Eight: %v", err)
+	}
+
+	if gameState.CurrentPlayerID() != "player1" {
+		t.Errorf("Expected player1 to play again, got %s", gameState.CurrentPlayerID())
+	}
+}
+
+// TestShouldSkipInNewDirection_WhenPlayingAceAfterReversal tests combining a reversal with an Ace skip
+func TestShouldSkipInNewDirection_WhenPlayingAceAfterReversal(t *testing.T) {
+	// Arrange
+	gameState := setupReversalTest("player1", "player2", "player3", "player4")
+	gameState.PlayCard("player1", card.NewCard(card.Hearts, card.Eight))
+
+	// Act
+	err := gameState.PlayCard("player4", card.NewCard(card.Hearts, card.Ace))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to play Ace: %v", err)
+	}
+
+	if gameState.CurrentPlayerID() != "player2" {
+		t.Errorf("Expected player3 to be skipped and player2 to play, got %s", gameState.CurrentPlayerID())
+	}
+}
+
+// TestShouldRestoreDirection_WhenReversingTwice tests that two reversals cancel out
+func TestShouldRestoreDirection_WhenReversingTwice(t *testing.T) {
+	// Arrange
+	gameState := setupReversalTest("player1", "player2", "player3")
+	gameState.PlayCard("player1", card.NewCard(card.Hearts, card.Eight))
+
+	// Act
+	err := gameState.PlayCard("player3", card.NewCard(card.Hearts, card.Eight))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to play Eight: %v", err)
+	}
+
+	if gameState.Direction != state.Clockwise {
+		t.Errorf("Expected clockwise direction, got %v", gameState.Direction)
+	}
+
+	if gameState.CurrentPlayerID() != "player1" {
+		t.Errorf("Expected player1 to play next, got %s", gameState.CurrentPlayerID())
+	}
+}
+
+// TestShouldSerializeDirection_WhenReversingBackToClockwise tests that a change to clockwise keeps its direction in JSON
+func TestShouldSerializeDirection_WhenReversingBackToClockwise(t *testing.T) {
+	// Arrange
+	gameState := setupReversalTest("player1", "player2", "player3")
+	gameState.Direction = state.CounterClockwise
+	if err := gameState.PlayCard("player1", card.NewCard(card.Hearts, card.Eight)); err != nil {
+		t.Fatalf("Failed to play Eight: %v", err)
+	}
+
+	var changed *state.Event
+	for _, e := range gameState.DrainEvents() {
+		if e.Type == state.EventDirectionChanged {
+			changed = &e
+		}
+	}
+	if changed == nil {
+		t.Fatal("Expected a direction change event")
+	}
+
+	// Act
+	data, err := json.Marshal(changed)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to serialize event: %v", err)
+	}
+
+	if !strings.Contains(string(data), `"direction":"CLOCKWISE"`) {
+		t.Errorf("Expected the event to carry the clockwise direction, got %s", data)
+	}
+}
@@ -13,6 +13,7 @@ import (
 func TestShouldReshuffleDiscardPile_WhenDrawPileIsEmpty(t *testing.T) {
 	// Arrange
 	player1 := player.New("player1")
+	player2 := player.New("player2") // Opponent, so the game is not over
 
 	// Create a state with empty draw pile and multiple cards in discard pile
 	discardPile := []card.Card{
@@ -32,8 +33,8 @@ func TestShouldReshuffleDiscardPile_WhenDrawPileIsEmpty(t *testing.T) {
 	}
 
 	gameState := &state.State{
-		Players:         []*player.Player{player1},
-		ActivePlayers:   []string{player1.ID},
+		Players:         []*player.Player{player1, player2},
+		ActivePlayers:   []string{player1.ID, player2.ID},
 		CurrentPlayerId: player1.ID,
 		Direction:       state.Clockwise,
 		DrawPile:        emptyDrawPile, // Empty draw pile
@@ -72,6 +73,7 @@ func TestShouldReshuffleDiscardPile_WhenDrawPileIsEmpty(t *testing.T) {
 func TestShouldHandleEmptyDrawPileInAttackChain_WhenAttackAmountIsLarge(t *testing.T) {
 	// Arrange
 	player1 := player.New("player1")
+	player2 := player.New("player2") // Opponent, so the game is not over
 
 	// Create a state with nearly empty draw pile, in an attack chain
 	drawPile := deck.New()
@@ -96,8 +98,8 @@ func TestShouldHandleEmptyDrawPileInAttackChain_WhenAttackAmountIsLarge(t *testi
 	topCard := discardPile[len(discardPile)-1]
 
 	gameState := &state.State{
-		Players:         []*player.Player{player1},
-		ActivePlayers:   []string{player1.ID},
+		Players:         []*player.Player{player1, player2},
+		ActivePlayers:   []string{player1.ID, player2.ID},
 		CurrentPlayerId: player1.ID,
 		Direction:       state.Clockwise,
 		DrawPile:        drawPile,
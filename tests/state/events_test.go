@@ -0,0 +1,209 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/djoufson/check-games-engine/card"
+	"github.com/djoufson/check-games-engine/deck"
+	"github.com/djoufson/check-games-engine/player"
+	"github.com/djoufson/check-games-engine/state"
+)
+
+// eventTypes returns the types of the given events in order
+func eventTypes(events []state.Event) []state.EventType {
+	types := make([]state.EventType, len(events))
+	for i, e := range events {
+		types[i] = e.Type
+	}
+	return types
+}
+
+// assertEventTypes checks that the events have exactly the expected types
+func assertEventTypes(t *testing.T, events []state.Event, expected ...state.EventType) {
+	t.Helper()
+	got := eventTypes(events)
+	if len(got) != len(expected) {
+		t.Fatalf("Expected events %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Fatalf("Expected events %v, got %v", expected, got)
+		}
+	}
+}
+
+// TestShouldEmitCardPlayed_WhenPlayingRegularCard tests the event of a regular play
+func TestShouldEmitCardPlayed_WhenPlayingRegularCard(t *testing.T) {
+	// Arrange
+	gameState, _, _ := setupCardPlayTest()
+
+	// Act
+	err := gameState.PlayCard("player1", card.NewCard(card.Hearts, card.King))
+	if err == nil {
+		t.Fatal("Expected King of Hearts to be rejected on the Queen of Spades")
+	}
+	gameState.DrainEvents()
+	gameState.FindPlayerByID("player1").AddToHand(card.NewCard(card.Spades, card.Five))
+	err = gameState.PlayCard("player1", card.NewCard(card.Spades, card.Five))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to play card: %v", err)
+	}
+
+	events := gameState.DrainEvents()
+	assertEventTypes(t, events, state.EventCardPlayed)
+	if events[0].PlayerID != "player1" || len(events[0].Cards) != 1 || events[0].Cards[0].Rank != card.Five {
+		t.Errorf("Unexpected card played event %+v", events[0])
+	}
+
+	if len(gameState.DrainEvents()) != 0 {
+		t.Error("Expected events to be cleared after draining")
+	}
+}
+
+// TestShouldEmitAttackEvents_WhenAttackChainIsPlayedAndResolved tests the attack lifecycle events
+func TestShouldEmitAttackEvents_WhenAttackChainIsPlayedAndResolved(t *testing.T) {
+	// Arrange
+	gameState, _, _ := setupAttackChainTest()
+
+	// Act & Assert
+	gameState.PlayCard("player1", card.NewCard(card.Hearts, card.Seven))
+	events := gameState.DrainEvents()
+	assertEventTypes(t, events, state.EventCardPlayed, state.EventAttackStarted)
+	if events[1].Amount != 2 {
+		t.Errorf("Expected attack to start at 2, got %d", events[1].Amount)
+	}
+
+	gameState.PlayCard("player2", card.NewCard(card.Spades, card.Seven))
+	events = gameState.DrainEvents()
+	assertEventTypes(t, events, state.EventCardPlayed, state.EventAttackEscalated)
+	if events[1].Amount != 4 {
+		t.Errorf("Expected attack to escalate to 4, got %d", events[1].Amount)
+	}
+
+	gameState.DrawCard("player1")
+	events = gameState.DrainEvents()
+	assertEventTypes(t, events, state.EventCardsDrawn, state.EventAttackResolved)
+	if events[0].Amount != 4 || len(events[0].Cards) != 4 {
+		t.Errorf("Expected 4 penalty cards to be drawn, got %+v", events[0])
+	}
+	if events[1].PlayerID != "player1" || events[1].Amount != 4 {
+		t.Errorf("Unexpected attack resolved event %+v", events[1])
+	}
+}
+
+// TestShouldEmitTurnSkipped_WhenPlayingAce tests the skip event with three players
+func TestShouldEmitTurnSkipped_WhenPlayingAce(t *testing.T) {
+	// Arrange
+	gameState, _, _ := setupCardPlayTest()
+	player3 := player.New("player3")
+	player3.AddToHand(card.NewCard(card.Clubs, card.Three))
+	gameState.Players = append(gameState.Players, player3)
+	gameState.ActivePlayers = append(gameState.ActivePlayers, player3.ID)
+
+	// Act
+	err := gameState.PlayCard("player1", card.NewCard(card.Spades, card.Ace))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to play Ace: %v", err)
+	}
+
+	events := gameState.DrainEvents()
+	assertEventTypes(t, events, state.EventCardPlayed, state.EventTurnSkipped)
+	if events[1].PlayerID != "player2" {
+		t.Errorf("Expected player2 to be skipped, got %s", events[1].PlayerID)
+	}
+}
+
+// TestShouldEmitSuitDeclared_WhenChangingSuit tests the suit declaration event
+func TestShouldEmitSuitDeclared_WhenChangingSuit(t *testing.T) {
+	// Arrange
+	gameState, _, _ := setupSuitChangerTest()
+	gameState.PlayCard("player1", card.NewCard(card.Clubs, card.Jack))
+	gameState.DrainEvents()
+
+	// Act
+	err := gameState.ChangeSuit("player1", card.Hearts)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to change suit: %v", err)
+	}
+
+	events := gameState.DrainEvents()
+	assertEventTypes(t, events, state.EventSuitDeclared)
+	if events[0].Suit != card.Hearts {
+		t.Errorf("Expected Hearts to be declared, got %v", events[0].Suit)
+	}
+}
+
+// TestShouldEmitPileReshuffled_WhenDrawPileIsEmpty tests the reshuffle event
+func TestShouldEmitPileReshuffled_WhenDrawPileIsEmpty(t *testing.T) {
+	// Arrange
+	gameState, _, _ := setupCardDrawingTest()
+	gameState.DrawPile = &deck.Deck{}
+	gameState.DiscardPile = []card.Card{
+		card.NewCard(card.Hearts, card.Five),
+		card.NewCard(card.Diamonds, card.Six),
+		gameState.TopCard,
+	}
+
+	// Act
+	err := gameState.DrawCard("player1")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to draw card: %v", err)
+	}
+
+	events := gameState.DrainEvents()
+	assertEventTypes(t, events, state.EventPileReshuffled, state.EventCardsDrawn)
+	if events[0].Amount != 2 {
+		t.Errorf("Expected 2 cards to be reshuffled, got %d", events[0].Amount)
+	}
+}
+
+// TestShouldEmitPlayerFinishedAndGameOver_WhenLastCardIsPlayed tests the end of game events
+func TestShouldEmitPlayerFinishedAndGameOver_WhenLastCardIsPlayed(t *testing.T) {
+	// Arrange
+	gameState, _, _ := setupLastCardTest()
+
+	// Act
+	err := gameState.PlayCard("player1", card.NewCard(card.Hearts, card.King))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to play card: %v", err)
+	}
+
+	events := gameState.DrainEvents()
+	assertEventTypes(t, events, state.EventCardPlayed, state.EventPlayerFinished, state.EventGameOver)
+	if events[1].PlayerID != "player1" {
+		t.Errorf("Expected player1 to finish, got %s", events[1].PlayerID)
+	}
+	if events[2].PlayerID != "player2" {
+		t.Errorf("Expected game over event to name the loser player2, got %s", events[2].PlayerID)
+	}
+}
+
+// TestShouldKeepEventsOnOriginal_WhenCloningState tests that a clone starts without the events still to drain
+func TestShouldKeepEventsOnOriginal_WhenCloningState(t *testing.T) {
+	// Arrange
+	gameState, _, _ := setupCardPlayTest()
+	gameState.FindPlayerByID("player1").AddToHand(card.NewCard(card.Spades, card.Five))
+	if err := gameState.PlayCard("player1", card.NewCard(card.Spades, card.Five)); err != nil {
+		t.Fatalf("Failed to play card: %v", err)
+	}
+
+	// Act
+	clone := gameState.Clone()
+
+	// Assert
+	if events := clone.DrainEvents(); len(events) != 0 {
+		t.Errorf("Expected the clone to have no events, got %v", eventTypes(events))
+	}
+
+	assertEventTypes(t, gameState.DrainEvents(), state.EventCardPlayed)
+}
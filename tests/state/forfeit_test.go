@@ -0,0 +1,294 @@
+package state_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/djoufson/check-games-engine/card"
+	"github.com/djoufson/check-games-engine/deck"
+	"github.com/djoufson/check-games-engine/player"
+	"github.com/djoufson/check-games-engine/state"
+)
+
+// setupForfeitTest creates a three-player game where player1 is to play
+func setupForfeitTest() *state.State {
+	player1 := player.New("player1")
+	player1.AddCardsToHand([]card.Card{
+		card.NewCard(card.Hearts, card.Seven),
+		card.NewCard(card.Hearts, card.Jack),
+		card.NewCard(card.Clubs, card.Four),
+	})
+
+	player2 := player.New("player2")
+	player2.AddCardsToHand([]card.Card{
+		card.NewCard(card.Spades, card.Seven),
+		card.NewCard(card.Diamonds, card.King),
+	})
+
+	player3 := player.New("player3")
+	player3.AddCardsToHand([]card.Card{
+		card.NewCard(card.Hearts, card.Queen),
+		card.NewCard(card.Clubs, card.Three),
+	})
+
+	topCard := card.NewCard(card.Hearts, card.Ten)
+	return &state.State{
+		Players:         []*player.Player{player1, player2, player3},
+		ActivePlayers:   []string{player1.ID, player2.ID, player3.ID},
+		CurrentPlayerId: player1.ID,
+		Direction:       state.Clockwise,
+		DrawPile:        &deck.Deck{},
+		DiscardPile:     []card.Card{topCard},
+		TopCard:         topCard,
+		LastActiveSuit:  card.Hearts,
+	}
+}
+
+// TestShouldRecordForfeitedHand_WhenPlayerForfeits tests that the hand given up is kept for scoring
+func TestShouldRecordForfeitedHand_WhenPlayerForfeits(t *testing.T) {
+	// Arrange
+	gameState := setupForfeitTest()
+	hand := slices.Clone(gameState.FindPlayerByID("player2").Hand)
+
+	// Act
+	err := gameState.Forfeit("player2")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to forfeit: %v", err)
+	}
+
+	if got := gameState.ForfeitedHand("player2"); !slices.Equal(got, hand) {
+		t.Errorf("Expected player2's forfeited hand to be %v, got %v", hand, got)
+	}
+
+	if got := gameState.Clone().ForfeitedHand("player2"); !slices.Equal(got, hand) {
+		t.Errorf("Expected the clone to keep the forfeited hand, got %v", got)
+	}
+}
+
+// TestShouldKeepStateValid_WhenPlayerForfeits tests that the hand set aside is not also dealt back into play
+func TestShouldKeepStateValid_WhenPlayerForfeits(t *testing.T) {
+	// Arrange
+	gameState := setupValidStateTest(t)
+
+	// Act
+	err := gameState.Forfeit(gameState.ActivePlayers[1])
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to forfeit: %v", err)
+	}
+
+	if err := gameState.Validate(); err != nil {
+		t.Errorf("Expected the state to stay valid, got %v", err)
+	}
+
+	cards := slices.Concat(gameState.DrawPile.Cards, gameState.DiscardPile)
+	for _, p := range gameState.Players {
+		cards = append(cards, p.Hand...)
+		cards = append(cards, gameState.ForfeitedHand(p.ID)...)
+	}
+	seen := make(map[int]bool)
+	for _, c := range cards {
+		if seen[c.ID] {
+			t.Errorf("Expected card ID %d to be in play only once", c.ID)
+		}
+		seen[c.ID] = true
+	}
+}
+
+// TestShouldKeepTurn_WhenAnotherPlayerForfeits tests that a forfeit out of turn does not change whose turn it is
+func TestShouldKeepTurn_WhenAnotherPlayerForfeits(t *testing.T) {
+	// Arrange
+	gameState := setupForfeitTest()
+
+	// Act
+	err := gameState.Forfeit("player2")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to forfeit: %v", err)
+	}
+
+	if gameState.CurrentPlayerID() != "player1" {
+		t.Errorf("Expected player1's turn, got %s", gameState.CurrentPlayerID())
+	}
+
+	if gameState.IsPlayerActive("player2") {
+		t.Error("Expected player2 to leave the game")
+	}
+
+	if gameState.DrawPile.Count() != 0 || !gameState.FindPlayerByID("player2").HasEmptyHand() {
+		t.Errorf("Expected player2's cards to be set aside, got %d cards in the draw pile", gameState.DrawPile.Count())
+	}
+
+	if next := gameState.NextPlayer(); next == nil || next.ID != "player3" {
+		t.Errorf("Expected player3 to play next, got %v", next)
+	}
+}
+
+// TestShouldPassTurn_WhenCurrentPlayerForfeits tests that the turn moves on when the current player forfeits
+func TestShouldPassTurn_WhenCurrentPlayerForfeits(t *testing.T) {
+	// Arrange
+	gameState := setupForfeitTest()
+	gameState.Direction = state.CounterClockwise
+
+	// Act
+	err := gameState.Apply(state.NewForfeitMove("player1"))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to forfeit: %v", err)
+	}
+
+	if gameState.CurrentPlayerID() != "player3" {
+		t.Errorf("Expected player3's turn, got %s", gameState.CurrentPlayerID())
+	}
+}
+
+// TestShouldEndAttack_WhenAttackedPlayerForfeits tests that an attack aimed at a forfeiting player ends with them
+func TestShouldEndAttack_WhenAttackedPlayerForfeits(t *testing.T) {
+	// Arrange
+	gameState := setupForfeitTest()
+	if err := gameState.PlayCard("player1", card.NewCard(card.Hearts, card.Seven)); err != nil {
+		t.Fatalf("Failed to attack: %v", err)
+	}
+
+	// Act
+	err := gameState.Forfeit("player2")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to forfeit: %v", err)
+	}
+
+	if gameState.InAttackChain || gameState.AttackAmount != 0 {
+		t.Errorf("Expected the attack to end, got amount %d", gameState.AttackAmount)
+	}
+
+	if gameState.CurrentPlayerID() != "player3" || gameState.CurrentPhase() != state.PhaseAwaitingPlay {
+		t.Errorf("Expected player3 to play normally, got %s in %s", gameState.CurrentPlayerID(), gameState.CurrentPhase())
+	}
+
+	if err := gameState.PlayCard("player3", card.NewCard(card.Hearts, card.Queen)); err != nil {
+		t.Errorf("Expected player3 to play a regular card, got %v", err)
+	}
+}
+
+// TestShouldKeepAttack_WhenBystanderForfeits tests that an attack on another player is not affected by a forfeit
+func TestShouldKeepAttack_WhenBystanderForfeits(t *testing.T) {
+	// Arrange
+	gameState := setupForfeitTest()
+	if err := gameState.PlayCard("player1", card.NewCard(card.Hearts, card.Seven)); err != nil {
+		t.Fatalf("Failed to attack: %v", err)
+	}
+
+	// Act
+	err := gameState.Forfeit("player3")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to forfeit: %v", err)
+	}
+
+	if !gameState.InAttackChain || gameState.AttackAmount != 2 {
+		t.Errorf("Expected the attack of 2 to go on, got %v with %d", gameState.InAttackChain, gameState.AttackAmount)
+	}
+
+	if gameState.CurrentPlayerID() != "player2" {
+		t.Errorf("Expected player2 to respond to the attack, got %s", gameState.CurrentPlayerID())
+	}
+}
+
+// TestShouldKeepJackSuit_WhenPlayerForfeitsBeforeDeclaring tests forfeiting while a suit must be declared
+func TestShouldKeepJackSuit_WhenPlayerForfeitsBeforeDeclaring(t *testing.T) {
+	// Arrange
+	gameState := setupForfeitTest()
+	if err := gameState.PlayCard("player1", card.NewCard(card.Hearts, card.Jack)); err != nil {
+		t.Fatalf("Failed to play Jack: %v", err)
+	}
+
+	// Act
+	err := gameState.Forfeit("player1")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to forfeit: %v", err)
+	}
+
+	if gameState.CurrentPhase() != state.PhaseAwaitingPlay || gameState.ActiveSuit() != card.Hearts {
+		t.Errorf("Expected play to go on in Hearts, got %s in %s", gameState.CurrentPhase(), gameState.ActiveSuit())
+	}
+
+	if gameState.CurrentPlayerID() != "player2" {
+		t.Errorf("Expected player2's turn, got %s", gameState.CurrentPlayerID())
+	}
+}
+
+// TestShouldRecordLoss_WhenPlayerForfeits tests that forfeiting players take the last places
+func TestShouldRecordLoss_WhenPlayerForfeits(t *testing.T) {
+	// Arrange
+	gameState := setupForfeitTest()
+
+	// Act
+	firstErr := gameState.Forfeit("player3")
+	secondErr := gameState.Forfeit("player1")
+
+	// Assert
+	if firstErr != nil || secondErr != nil {
+		t.Fatalf("Failed to forfeit: %v, %v", firstErr, secondErr)
+	}
+
+	if !gameState.IsGameOver() {
+		t.Fatal("Expected the game to be over")
+	}
+
+	want := []string{"player2", "player1", "player3"}
+	standings := gameState.Standings()
+	if len(standings) != len(want) {
+		t.Fatalf("Expected %d placements, got %d", len(want), len(standings))
+	}
+	for i, id := range want {
+		if standings[i].PlayerID != id || standings[i].Place != i+1 {
+			t.Errorf("Expected %s in place %d, got %+v", id, i+1, standings[i])
+		}
+	}
+
+	if loser := gameState.GetLoser(); loser != "player3" {
+		t.Errorf("Expected player3 to lose, got %s", loser)
+	}
+
+	if winners := gameState.GetWinner(); len(winners) != 1 || winners[0] != "player2" {
+		t.Errorf("Expected player2 to win, got %v", winners)
+	}
+}
+
+// TestShouldRejectForfeit_WhenPlayerIsNotActive tests forfeiting twice or after the game is over
+func TestShouldRejectForfeit_WhenPlayerIsNotActive(t *testing.T) {
+	// Arrange
+	gameState := setupForfeitTest()
+	if err := gameState.Forfeit("player3"); err != nil {
+		t.Fatalf("Failed to forfeit: %v", err)
+	}
+
+	// Act
+	againErr := gameState.Forfeit("player3")
+	unknownErr := gameState.Forfeit("nobody")
+	_ = gameState.Forfeit("player2")
+	overErr := gameState.Forfeit("player1")
+
+	// Assert
+	if !errors.Is(againErr, state.ErrPlayerNotFound) {
+		t.Errorf("Expected ErrPlayerNotFound for a second forfeit, got %v", againErr)
+	}
+
+	if !errors.Is(unknownErr, state.ErrPlayerNotFound) {
+		t.Errorf("Expected ErrPlayerNotFound for an unknown player, got %v", unknownErr)
+	}
+
+	if !errors.Is(overErr, state.ErrGameOver) {
+		t.Errorf("Expected ErrGameOver, got %v", overErr)
+	}
+}
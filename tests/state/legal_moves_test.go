@@ -0,0 +1,153 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/djoufson/check-games-engine/card"
+	"github.com/djoufson/check-games-engine/state"
+)
+
+// countMoves counts the moves of each type
+func countMoves(moves []state.Move) map[state.MoveType]int {
+	counts := make(map[state.MoveType]int)
+	for _, m := range moves {
+		counts[m.Type]++
+	}
+	return counts
+}
+
+// TestShouldListPlayableCardsAndDraw_WhenItIsPlayersTurn tests legal moves in normal play
+func TestShouldListPlayableCardsAndDraw_WhenItIsPlayersTurn(t *testing.T) {
+	// Arrange
+	gameState, _, _ := setupCardDrawingTest()
+	player1 := gameState.FindPlayerByID("player1")
+	player1.AddCardsToHand([]card.Card{
+		card.NewCard(gameState.TopCard.Suit, card.Five), // Matches by suit
+		card.NewCard(card.Hearts, card.Two),             // Transparent
+	})
+
+	// Act
+	moves := gameState.LegalMoves("player1")
+
+	// Assert
+	counts := countMoves(moves)
+	if counts[state.MovePlayCard] != 2 {
+		t.Errorf("Expected 2 play moves, got %d", counts[state.MovePlayCard])
+	}
+
+	if counts[state.MoveDrawCard] != 1 {
+		t.Errorf("Expected 1 draw move, got %d", counts[state.MoveDrawCard])
+	}
+
+	if counts[state.MoveChangeSuit] != 0 {
+		t.Errorf("Expected no suit declarations, got %d", counts[state.MoveChangeSuit])
+	}
+}
+
+// TestShouldReturnEmptyList_WhenItIsNotPlayersTurn tests legal moves for a waiting player
+func TestShouldReturnEmptyList_WhenItIsNotPlayersTurn(t *testing.T) {
+	// Arrange
+	gameState, _, _ := setupCardPlayTest()
+
+	// Act
+	moves := gameState.LegalMoves("player2")
+
+	// Assert
+	if moves == nil || len(moves) != 0 {
+		t.Errorf("Expected an empty list of moves, got %v", moves)
+	}
+}
+
+// TestShouldOnlyListSuitDeclarations_WhenTurnIsLocked tests legal moves after playing a Jack
+func TestShouldOnlyListSuitDeclarations_WhenTurnIsLocked(t *testing.T) {
+	// Arrange
+	gameState, _, _ := setupSuitChangerTest()
+	if err := gameState.PlayCard("player1", card.NewCard(card.Clubs, card.Jack)); err != nil {
+		t.Fatalf("Failed to play Jack: %v", err)
+	}
+
+	// Act
+	moves := gameState.LegalMoves("player1")
+
+	// Assert
+	// Player1 is down to one card, so announcing "Check!" is legal too
+	counts := countMoves(moves)
+	if counts[state.MoveChangeSuit] != 4 {
+		t.Fatalf("Expected 4 suit declarations, got %v", moves)
+	}
+
+	if counts[state.MovePlayCard] != 0 || counts[state.MoveDrawCard] != 0 {
+		t.Errorf("Expected no plays or draws while the turn is locked, got %v", moves)
+	}
+
+	if err := gameState.DrawCard("player1"); err == nil {
+		t.Error("Expected drawing to be rejected while the turn is locked")
+	}
+}
+
+// TestShouldListWildCardsAndForcedDraw_WhenInAttackChain tests legal moves while under attack
+func TestShouldListWildCardsAndForcedDraw_WhenInAttackChain(t *testing.T) {
+	// Arrange
+	gameState, _, _ := setupAttackChainTest()
+	if err := gameState.PlayCard("player1", card.NewCard(card.Hearts, card.Seven)); err != nil {
+		t.Fatalf("Failed to play Seven: %v", err)
+	}
+
+	// Act
+	moves := gameState.LegalMoves("player2")
+
+	// Assert
+	counts := countMoves(moves)
+	if counts[state.MovePlayCard] != 1 || counts[state.MoveDrawCard] != 1 || counts[state.MoveChangeSuit] != 0 {
+		t.Fatalf("Expected the Seven of Spades and a forced draw, got %v", moves)
+	}
+
+	for _, m := range moves {
+		if m.Type == state.MovePlayCard && !m.Card.IsWildCard() {
+			t.Errorf("Expected only wild cards during an attack, got %v", *m.Card)
+		}
+	}
+}
+
+// TestShouldAgreeWithActions_WhenApplyingLegalMoves tests that legal moves always apply and other plays fail
+func TestShouldAgreeWithActions_WhenApplyingLegalMoves(t *testing.T) {
+	// Arrange
+	gameState, err := state.New([]string{"player1", "player2", "player3"}, &state.GameOptions{
+		InitialCards: 7,
+		RandomSeed:   42,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create new game: %v", err)
+	}
+
+	for turn := 0; turn < 200 && !gameState.IsGameOver(); turn++ {
+		playerID := gameState.CurrentPlayerID()
+		moves := gameState.LegalMoves(playerID)
+		if len(moves) == 0 {
+			t.Fatalf("Expected at least one legal move for %s on turn %d", playerID, turn)
+		}
+
+		// Every card that is not listed must be rejected by PlayCard
+		for _, c := range gameState.FindPlayerByID(playerID).Hand {
+			listed := false
+			for _, m := range moves {
+				if m.Type == state.MovePlayCard && *m.Card == c {
+					listed = true
+				}
+			}
+			if !listed && gameState.Clone().PlayCard(playerID, c) == nil {
+				t.Fatalf("Expected unlisted card %v to be rejected on turn %d", c, turn)
+			}
+		}
+
+		// Act
+		// Prefer playing a card so the game makes progress
+		move := moves[0]
+		err := gameState.Apply(move)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Failed to apply legal move %+v on turn %d: %v", move, turn, err)
+		}
+	}
+}
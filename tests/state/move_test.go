@@ -0,0 +1,143 @@
+package state_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/djoufson/check-games-engine/card"
+	"github.com/djoufson/check-games-engine/state"
+)
+
+// TestShouldPlayCard_WhenApplyingPlayCardMove tests applying a play move
+func TestShouldPlayCard_WhenApplyingPlayCardMove(t *testing.T) {
+	// Arrange
+	gameState, player1, _ := setupCardPlayTest()
+	aceSpades := card.NewCard(card.Spades, card.Ace)
+
+	// Act
+	err := gameState.Apply(state.NewPlayCardMove("player1", aceSpades))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to apply play move: %v", err)
+	}
+
+	if gameState.TopCard.Suit != card.Spades || gameState.TopCard.Rank != card.Ace {
+		t.Errorf("Expected top card to be Ace of Spades, got %v", gameState.TopCard)
+	}
+
+	if player1.HasCard(aceSpades) {
+		t.Error("Expected player1's hand to no longer contain Ace of Spades")
+	}
+}
+
+// TestShouldDrawCard_WhenApplyingDrawCardMove tests applying a draw move
+func TestShouldDrawCard_WhenApplyingDrawCardMove(t *testing.T) {
+	// Arrange
+	gameState, player1, _ := setupCardDrawingTest()
+	initialHandSize := len(player1.Hand)
+
+	// Act
+	err := gameState.Apply(state.NewDrawCardMove("player1"))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to apply draw move: %v", err)
+	}
+
+	if len(player1.Hand) != initialHandSize+1 {
+		t.Errorf("Expected hand size to increase by 1, got %d", len(player1.Hand))
+	}
+
+	if gameState.CurrentPlayerID() != "player2" {
+		t.Errorf("Expected current player to be player2, got %s", gameState.CurrentPlayerID())
+	}
+}
+
+// TestShouldChangeSuit_WhenApplyingChangeSuitMove tests applying a suit declaration move
+func TestShouldChangeSuit_WhenApplyingChangeSuitMove(t *testing.T) {
+	// Arrange
+	gameState, _, _ := setupSuitChangerTest()
+	err := gameState.Apply(state.NewPlayCardMove("player1", card.NewCard(card.Clubs, card.Jack)))
+	if err != nil {
+		t.Fatalf("Failed to play Jack: %v", err)
+	}
+
+	// Act
+	err = gameState.Apply(state.NewChangeSuitMove("player1", card.Spades))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to apply change suit move: %v", err)
+	}
+
+	if gameState.LastActiveSuit != card.Spades {
+		t.Errorf("Expected active suit to be Spades, got %v", gameState.LastActiveSuit)
+	}
+}
+
+// TestShouldReturnError_WhenApplyingUnknownMove tests that unknown move types are rejected
+func TestShouldReturnError_WhenApplyingUnknownMove(t *testing.T) {
+	// Arrange
+	gameState, _, _ := setupCardPlayTest()
+
+	// Act
+	err := gameState.Apply(state.Move{Type: "DANCE", PlayerID: "player1"})
+
+	// Assert
+	if err == nil {
+		t.Error("Expected error when applying unknown move type")
+	}
+}
+
+// TestShouldReturnError_WhenApplyingPlayMoveWithoutCard tests that play moves require a card
+func TestShouldReturnError_WhenApplyingPlayMoveWithoutCard(t *testing.T) {
+	// Arrange
+	gameState, _, _ := setupCardPlayTest()
+
+	// Act
+	err := gameState.Apply(state.Move{Type: state.MovePlayCard, PlayerID: "player1"})
+
+	// Assert
+	if err == nil {
+		t.Error("Expected error when applying play move without a card")
+	}
+}
+
+// TestShouldPreserveMove_WhenRoundTrippingThroughJSON tests move serialization
+func TestShouldPreserveMove_WhenRoundTrippingThroughJSON(t *testing.T) {
+	// Arrange
+	moves := []state.Move{
+		state.NewPlayCardMove("player1", card.NewCard(card.Hearts, card.Seven)),
+		state.NewDrawCardMove("player2"),
+		state.NewChangeSuitMove("player1", card.Diamonds),
+	}
+
+	for _, move := range moves {
+		// Act
+		data, err := json.Marshal(move)
+		if err != nil {
+			t.Fatalf("Failed to marshal move: %v", err)
+		}
+
+		var decoded state.Move
+		err = json.Unmarshal(data, &decoded)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Failed to unmarshal move: %v", err)
+		}
+
+		if decoded.Type != move.Type || decoded.PlayerID != move.PlayerID || decoded.Suit != move.Suit {
+			t.Errorf("Expected %+v, got %+v", move, decoded)
+		}
+
+		if (decoded.Card == nil) != (move.Card == nil) {
+			t.Fatalf("Expected card presence to be preserved for %s", move.Type)
+		}
+
+		if move.Card != nil && *decoded.Card != *move.Card {
+			t.Errorf("Expected card %v, got %v", *move.Card, *decoded.Card)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package state_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/djoufson/check-games-engine/card"
+	"github.com/djoufson/check-games-engine/deck"
+	"github.com/djoufson/check-games-engine/player"
+	"github.com/djoufson/check-games-engine/rules"
+	"github.com/djoufson/check-games-engine/state"
+)
+
+// setupMultiPlayTest creates a four player state where several cards can be played at once
+func setupMultiPlayTest(multiPlay bool) *state.State {
+	rs := rules.Default()
+	rs.MultiPlay = multiPlay
+
+	players := make([]*player.Player, 0, 4)
+	ids := []string{"player1", "player2", "player3", "player4"}
+	for _, id := range ids {
+		p := player.New(id)
+		p.AddCardsToHand([]card.Card{card.NewCard(card.Diamonds, card.Four)})
+		players = append(players, p)
+	}
+	players[0].AddCardsToHand([]card.Card{
+		card.NewCard(card.Hearts, card.Seven),
+		card.NewCard(card.Clubs, card.Seven),
+		card.NewCard(card.Hearts, card.Ace),
+		card.NewCard(card.Spades, card.Ace),
+		card.NewCard(card.Clubs, card.Nine),
+	})
+
+	return &state.State{
+		Players:         players,
+		ActivePlayers:   ids,
+		CurrentPlayerId: "player1",
+		Direction:       state.Clockwise,
+		DrawPile:        deck.New(),
+		DiscardPile:     []card.Card{card.NewCard(card.Hearts, card.Queen)},
+		TopCard:         card.NewCard(card.Hearts, card.Queen),
+		LastActiveSuit:  card.Hearts,
+		Ruleset:         &rs,
+	}
+}
+
+// TestShouldAddPenalties_WhenPlayingTwoSevens tests that combined attacks add up
+func TestShouldAddPenalties_WhenPlayingTwoSevens(t *testing.T) {
+	// Arrange
+	gameState := setupMultiPlayTest(true)
+	sevens := []card.Card{card.NewCard(card.Hearts, card.Seven), card.NewCard(card.Clubs, card.Seven)}
+
+	// Act
+	err := gameState.PlayCards("player1", sevens)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to play Sevens: %v", err)
+	}
+
+	if !gameState.InAttackChain || gameState.AttackAmount != 4 {
+		t.Errorf("Expected an attack of 4, got %v/%d", gameState.InAttackChain, gameState.AttackAmount)
+	}
+
+	if gameState.TopCard != sevens[1] {
+		t.Errorf("Expected the last Seven on top, got %v", gameState.TopCard)
+	}
+
+	if gameState.CurrentPlayerID() != "player2" {
+		t.Errorf("Expected player2 to defend, got %s", gameState.CurrentPlayerID())
+	}
+}
+
+// TestShouldSkipTwoPlayers_WhenPlayingTwoAces tests that combined skips add up
+func TestShouldSkipTwoPlayers_WhenPlayingTwoAces(t *testing.T) {
+	// Arrange
+	gameState := setupMultiPlayTest(true)
+	aces := []card.Card{card.NewCard(card.Hearts, card.Ace), card.NewCard(card.Spades, card.Ace)}
+
+	// Act
+	err := gameState.PlayCards("player1", aces)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to play Aces: %v", err)
+	}
+
+	if gameState.CurrentPlayerID() != "player4" {
+		t.Errorf("Expected player2 and player3 to be skipped, got %s", gameState.CurrentPlayerID())
+	}
+
+	if gameState.ActiveSuit() != card.Spades {
+		t.Errorf("Expected the last Ace to set the suit, got %s", gameState.ActiveSuit())
+	}
+
+	skipped := 0
+	for _, e := range gameState.DrainEvents() {
+		if e.Type == state.EventTurnSkipped {
+			skipped++
+		}
+	}
+	if skipped != 2 {
+		t.Errorf("Expected 2 skip events, got %d", skipped)
+	}
+}
+
+// TestShouldRejectMultiPlay_WhenRuleIsDisabled tests the rule option
+func TestShouldRejectMultiPlay_WhenRuleIsDisabled(t *testing.T) {
+	// Arrange
+	gameState := setupMultiPlayTest(false)
+	aces := []card.Card{card.NewCard(card.Hearts, card.Ace), card.NewCard(card.Spades, card.Ace)}
+
+	// Act
+	err := gameState.PlayCards("player1", aces)
+
+	// Assert
+	if !errors.Is(err, state.ErrMultiPlayDisabled) {
+		t.Errorf("Expected ErrMultiPlayDisabled, got %v", err)
+	}
+
+	for _, m := range gameState.LegalMoves("player1") {
+		if m.Type == state.MovePlayCards {
+			t.Errorf("Expected no multi-card moves, got %v", m)
+		}
+	}
+}
+
+// TestShouldValidateOnlyFirstCard_WhenPlayingSeveralCards tests which card is matched against the top card
+func TestShouldValidateOnlyFirstCard_WhenPlayingSeveralCards(t *testing.T) {
+	// Arrange
+	gameState := setupMultiPlayTest(true)
+	player1 := gameState.FindPlayerByID("player1")
+	player1.AddToHand(card.NewCard(card.Hearts, card.Nine))
+
+	// Act
+	wrongFirst := gameState.PlayCards("player1", []card.Card{card.NewCard(card.Clubs, card.Nine), card.NewCard(card.Hearts, card.Nine)})
+	mixed := gameState.PlayCards("player1", []card.Card{card.NewCard(card.Hearts, card.Nine), card.NewCard(card.Clubs, card.Seven)})
+	err := gameState.PlayCards("player1", []card.Card{card.NewCard(card.Hearts, card.Nine), card.NewCard(card.Clubs, card.Nine)})
+
+	// Assert
+	if !errors.Is(wrongFirst, state.ErrInvalidPlay) {
+		t.Errorf("Expected ErrInvalidPlay for a first card that does not match, got %v", wrongFirst)
+	}
+
+	if !errors.Is(mixed, state.ErrMixedRanks) {
+		t.Errorf("Expected ErrMixedRanks, got %v", mixed)
+	}
+
+	if err != nil {
+		t.Fatalf("Failed to play Nines: %v", err)
+	}
+
+	if gameState.ActiveSuit() != card.Clubs {
+		t.Errorf("Expected Clubs to be active, got %s", gameState.ActiveSuit())
+	}
+}
+
+// TestShouldOfferSameRankMoves_WhenMultiPlayIsEnabled tests legal multi-card moves
+func TestShouldOfferSameRankMoves_WhenMultiPlayIsEnabled(t *testing.T) {
+	// Arrange
+	gameState := setupMultiPlayTest(true)
+
+	// Act
+	moves := gameState.LegalMoves("player1")
+
+	// Assert
+	for _, m := range moves {
+		if m.Type != state.MovePlayCards {
+			continue
+		}
+		if len(m.Cards) != 2 || m.Cards[0].Suit != card.Hearts {
+			t.Errorf("Expected pairs led by a Heart, got %v", m.Cards)
+		}
+		if err := gameState.CheckMove(m); err != nil {
+			t.Errorf("Expected listed move to be valid, got %v", err)
+		}
+	}
+
+	if counts := countMoves(moves); counts[state.MovePlayCards] != 2 {
+		t.Errorf("Expected 2 multi-card moves, got %d", counts[state.MovePlayCards])
+	}
+}
@@ -0,0 +1,129 @@
+package state_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/djoufson/check-games-engine/card"
+	"github.com/djoufson/check-games-engine/state"
+)
+
+// TestShouldReachTarget_WhenApplyingDiffOfEachMove tests patches over a whole game, including reshuffles
+func TestShouldReachTarget_WhenApplyingDiffOfEachMove(t *testing.T) {
+	// Arrange
+	gameState := setupValidStateTest(t)
+	client := gameState.Clone()
+
+	for turn := 0; turn < 300 && !gameState.IsGameOver(); turn++ {
+		moves := gameState.LegalMoves(gameState.CurrentPlayerID())
+		if err := gameState.Apply(moves[turn%len(moves)]); err != nil {
+			t.Fatalf("Failed to apply legal move on turn %d: %v", turn, err)
+		}
+
+		// Act
+		patch, err := state.Diff(client, gameState)
+		if err != nil {
+			t.Fatalf("Failed to diff turn %d: %v", turn, err)
+		}
+		data, err := json.Marshal(patch)
+		if err != nil {
+			t.Fatalf("Failed to serialize patch of turn %d: %v", turn, err)
+		}
+		var decoded state.Patch
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("Failed to deserialize patch of turn %d: %v", turn, err)
+		}
+		err = client.ApplyPatch(&decoded)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Failed to apply patch of turn %d: %v", turn, err)
+		}
+		if !client.Equal(gameState) {
+			t.Fatalf("Expected the patched state to equal the target after turn %d", turn)
+		}
+	}
+}
+
+// TestShouldProduceSmallPatch_WhenDrawingACard tests that a draw only carries the card and the turn change
+func TestShouldProduceSmallPatch_WhenDrawingACard(t *testing.T) {
+	// Arrange
+	gameState := setupValidStateTest(t)
+	before := gameState.Clone()
+	drawn := gameState.DrawPile.Cards[0]
+	if err := gameState.DrawCard("player1"); err != nil {
+		t.Fatalf("Failed to draw: %v", err)
+	}
+
+	// Act
+	patch, err := state.Diff(before, gameState)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to diff: %v", err)
+	}
+
+	if len(patch.Hands) != 1 || patch.Hands[0].PlayerID != "player1" || len(patch.Hands[0].Added) != 1 || patch.Hands[0].Added[0] != drawn {
+		t.Errorf("Expected player1 to gain %v, got %+v", drawn, patch.Hands)
+	}
+
+	if patch.DrawPile == nil || patch.DrawPile.Drop != 1 || len(patch.DrawPile.Push) != 0 {
+		t.Errorf("Expected one card off the draw pile, got %+v", patch.DrawPile)
+	}
+
+	if patch.DiscardPile != nil {
+		t.Errorf("Expected the discard pile to be unchanged, got %+v", patch.DiscardPile)
+	}
+
+	if _, ok := patch.Fields["current_player_id"]; !ok {
+		t.Errorf("Expected the current player to change, got %v", patch.Fields)
+	}
+}
+
+// TestShouldRejectPatch_WhenStateDoesNotMatch tests applying a patch to the wrong state
+func TestShouldRejectPatch_WhenStateDoesNotMatch(t *testing.T) {
+	// Arrange
+	gameState := setupValidStateTest(t)
+	before := gameState.Clone()
+	played := gameState.LegalMoves("player1")[0]
+	if err := gameState.Apply(played); err != nil {
+		t.Fatalf("Failed to apply move: %v", err)
+	}
+	patch, _ := state.Diff(before, gameState)
+
+	// Act
+	stale := gameState.Clone()
+	err := stale.ApplyPatch(patch)
+
+	// Assert
+	if !errors.Is(err, state.ErrPatchMismatch) {
+		t.Errorf("Expected ErrPatchMismatch, got %v", err)
+	}
+
+	if !stale.Equal(gameState) {
+		t.Error("Expected a rejected patch to leave the state unchanged")
+	}
+}
+
+// TestShouldCompareStates_WhenCheckingEquality tests Equal on clones and changed states
+func TestShouldCompareStates_WhenCheckingEquality(t *testing.T) {
+	// Arrange
+	gameState := setupValidStateTest(t)
+	clone := gameState.Clone()
+	changed := gameState.Clone()
+	changed.Players[0].AddToHand(card.NewRedJoker())
+
+	// Act & Assert
+	if !gameState.Equal(clone) {
+		t.Error("Expected a clone to be equal")
+	}
+
+	if gameState.Equal(changed) {
+		t.Error("Expected a changed hand to make the states differ")
+	}
+
+	if gameState.Equal(nil) {
+		t.Error("Expected a state not to equal nil")
+	}
+}
@@ -0,0 +1,209 @@
+package state_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/djoufson/check-games-engine/card"
+	"github.com/djoufson/check-games-engine/state"
+)
+
+// TestShouldFollowPhases_WhenPlayingThroughTurns tests the phase transitions of a sequence of moves
+func TestShouldFollowPhases_WhenPlayingThroughTurns(t *testing.T) {
+	// Arrange
+	gameState, _, _ := setupAttackChainTest()
+	gameState.FindPlayerByID("player1").AddToHand(card.NewCard(card.Hearts, card.Jack))
+
+	steps := []struct {
+		name  string
+		apply func() error
+		want  state.Phase
+	}{
+		{"initial", func() error { return nil }, state.PhaseAwaitingPlay},
+		{"attack", func() error { return gameState.PlayCard("player1", card.NewCard(card.Hearts, card.Seven)) }, state.PhaseAwaitingAttackResponse},
+		{"escalate", func() error { return gameState.PlayCard("player2", card.NewCard(card.Spades, card.Seven)) }, state.PhaseAwaitingAttackResponse},
+		{"penalty", func() error { return gameState.DrawCard("player1") }, state.PhaseAwaitingPlay},
+		{"draw", func() error { return gameState.DrawCard("player2") }, state.PhaseAwaitingPlay},
+		{"jack", func() error { return gameState.PlayCard("player1", card.NewCard(card.Hearts, card.Jack)) }, state.PhaseAwaitingSuitChoice},
+		{"suit", func() error { return gameState.ChangeSuit("player1", card.Clubs) }, state.PhaseAwaitingPlay},
+	}
+
+	// Act & Assert
+	for _, step := range steps {
+		if err := step.apply(); err != nil {
+			t.Fatalf("Step %s failed: %v", step.name, err)
+		}
+
+		if phase := gameState.CurrentPhase(); phase != step.want {
+			t.Errorf("Expected phase %s after %s, got %s", step.want, step.name, phase)
+		}
+	}
+}
+
+// TestShouldRejectActions_WhenGameIsOver tests that nothing can be played once the game is finished
+func TestShouldRejectActions_WhenGameIsOver(t *testing.T) {
+	// Arrange
+	gameState, _, _ := setupLastCardTest()
+	if err := gameState.PlayCard("player1", card.NewCard(card.Hearts, card.King)); err != nil {
+		t.Fatalf("Failed to play last card: %v", err)
+	}
+
+	// Act
+	playErr := gameState.PlayCard("player2", card.NewCard(card.Hearts, card.Queen))
+	drawErr := gameState.DrawCard("player2")
+	suitErr := gameState.ChangeSuit("player2", card.Clubs)
+
+	// Assert
+	if gameState.CurrentPhase() != state.PhaseFinished {
+		t.Errorf("Expected the game to be finished, got %s", gameState.CurrentPhase())
+	}
+
+	for _, err := range []error{playErr, drawErr, suitErr} {
+		if !errors.Is(err, state.ErrGameOver) {
+			t.Errorf("Expected ErrGameOver, got %v", err)
+		}
+	}
+
+	if moves := gameState.LegalMoves("player2"); len(moves) != 0 {
+		t.Errorf("Expected no legal moves, got %v", moves)
+	}
+}
+
+// TestShouldKeepPhase_WhenCloningAndSerializing tests that the phase survives Clone and JSON
+func TestShouldKeepPhase_WhenCloningAndSerializing(t *testing.T) {
+	// Arrange
+	gameState, _, _ := setupSuitChangerTest()
+	if err := gameState.PlayCard("player1", card.NewCard(card.Clubs, card.Jack)); err != nil {
+		t.Fatalf("Failed to play Jack: %v", err)
+	}
+	completeDrawPile(gameState)
+	data, _ := gameState.ToJSON()
+
+	// Act
+	clone := gameState.Clone()
+	restored, err := state.FromJSON(data)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to deserialize state: %v", err)
+	}
+
+	for _, s := range []*state.State{clone, restored} {
+		if s.CurrentPhase() != state.PhaseAwaitingSuitChoice || !s.LockedTurn {
+			t.Errorf("Expected a locked turn awaiting a suit, got %s/%v", s.CurrentPhase(), s.LockedTurn)
+		}
+
+		if err := s.DrawCard("player1"); !errors.Is(err, state.ErrTurnLocked) {
+			t.Errorf("Expected ErrTurnLocked, got %v", err)
+		}
+	}
+}
+
+// TestShouldDerivePhase_WhenStateHasNoRecordedPhase tests states built without a phase
+func TestShouldDerivePhase_WhenStateHasNoRecordedPhase(t *testing.T) {
+	// Arrange
+	gameState, _, _ := setupCardPlayTest()
+	gameState.InAttackChain = true
+	gameState.AttackAmount = 2
+
+	// Act
+	phase := gameState.CurrentPhase()
+
+	// Assert
+	if phase != state.PhaseAwaitingAttackResponse {
+		t.Errorf("Expected phase %s, got %s", state.PhaseAwaitingAttackResponse, phase)
+	}
+}
+
+// applyWithoutPanic applies the move and fails the test if the engine panics
+func applyWithoutPanic(t *testing.T, gameState *state.State, m state.Move) {
+	t.Helper()
+
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("Applying %s by %s panicked: %v", m.Type, m.PlayerID, r)
+		}
+	}()
+
+	_ = gameState.Apply(m)
+}
+
+// TestShouldNeverPanic_WhenPlayingFromAnyValidState tests that every phase a save may claim can be played safely
+func TestShouldNeverPanic_WhenPlayingFromAnyValidState(t *testing.T) {
+	phases := []state.Phase{"", state.PhaseAwaitingPlay, state.PhaseAwaitingSuitChoice, state.PhaseAwaitingAttackResponse, state.PhaseFinished}
+
+	for _, phase := range phases {
+		for _, locked := range []bool{false, true} {
+			for _, attack := range []bool{false, true} {
+				// Arrange
+				gameState := setupValidStateTest(t)
+				gameState.Phase, gameState.LockedTurn, gameState.InAttackChain = phase, locked, attack
+				if attack {
+					gameState.AttackAmount = 2
+				}
+				if gameState.Validate() != nil {
+					continue
+				}
+
+				// Act & Assert
+				for step := 0; step < 100 && !gameState.IsGameOver(); step++ {
+					moves := gameState.LegalMoves(gameState.CurrentPlayerID())
+					for _, id := range gameState.ActivePlayers {
+						moves = append(moves, state.NewForfeitMove(id), state.NewChangeSuitMove(id, card.Clubs))
+					}
+					for _, m := range moves {
+						applyWithoutPanic(t, gameState.Clone(), m)
+					}
+					applyWithoutPanic(t, gameState, moves[step%len(moves)])
+				}
+			}
+		}
+	}
+}
+
+// TestShouldNeverPanic_WhenPlayingFromHandBuiltState tests states that skipped Validate, including unknown phases
+func TestShouldNeverPanic_WhenPlayingFromHandBuiltState(t *testing.T) {
+	phases := []state.Phase{"", state.PhaseAwaitingPlay, state.PhaseAwaitingSuitChoice, state.PhaseAwaitingAttackResponse, state.PhaseFinished, "UNKNOWN"}
+
+	for _, phase := range phases {
+		for _, locked := range []bool{false, true} {
+			for _, attack := range []bool{false, true} {
+				// Arrange
+				gameState := setupValidStateTest(t)
+				gameState.Phase, gameState.LockedTurn, gameState.InAttackChain = phase, locked, attack
+
+				// Act & Assert
+				for _, id := range gameState.ActivePlayers {
+					applyWithoutPanic(t, gameState.Clone(), state.NewForfeitMove(id))
+				}
+				for _, m := range gameState.LegalMoves(gameState.CurrentPlayerID()) {
+					applyWithoutPanic(t, gameState.Clone(), m)
+				}
+			}
+		}
+	}
+}
+
+// TestShouldRejectForfeit_WhenHandBuiltStateIsFinished tests a finished phase recorded while players remain
+func TestShouldRejectForfeit_WhenHandBuiltStateIsFinished(t *testing.T) {
+	// Arrange
+	gameState := setupCheckCallTest()
+	gameState.Phase = state.PhaseFinished
+
+	// Act
+	forfeitErr := gameState.Forfeit("player1")
+	lockErr := gameState.LockTurn()
+
+	// Assert
+	if !errors.Is(forfeitErr, state.ErrGameOver) {
+		t.Errorf("Expected ErrGameOver, got %v", forfeitErr)
+	}
+
+	if !errors.Is(lockErr, state.ErrInvalidState) {
+		t.Errorf("Expected ErrInvalidState, got %v", lockErr)
+	}
+
+	if len(gameState.ActivePlayers) != 3 || gameState.FindPlayerByID("player1").HandSize() != 2 {
+		t.Error("Expected the rejected forfeit to leave the state unchanged")
+	}
+}
@@ -0,0 +1,132 @@
+package state_test
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/djoufson/check-games-engine/state"
+)
+
+// playScripted applies a fixed, draw-heavy strategy so that the draw pile gets reshuffled
+func playScripted(t *testing.T, gameState *state.State, turns int) {
+	for turn := 0; turn < turns && !gameState.IsGameOver(); turn++ {
+		moves := gameState.LegalMoves(gameState.CurrentPlayerID())
+		if len(moves) == 0 {
+			t.Fatalf("No legal moves on turn %d", turn)
+		}
+
+		move := moves[0]
+		if turn%3 != 0 {
+			for _, m := range moves {
+				if m.Type == state.MoveDrawCard {
+					move = m
+				}
+			}
+		}
+
+		if err := gameState.Apply(move); err != nil {
+			t.Fatalf("Failed to apply move on turn %d: %v", turn, err)
+		}
+	}
+}
+
+// newSeededState creates a new two player state with a fixed seed
+func newSeededState(t *testing.T) *state.State {
+	gameState, err := state.New([]string{"player1", "player2"}, &state.GameOptions{
+		InitialCards: 7,
+		RandomSeed:   2024,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create new game: %v", err)
+	}
+	return gameState
+}
+
+// TestShouldMatchMathRand_WhenUsingSameSeed tests that the RNG keeps the math/rand stream for a seed
+func TestShouldMatchMathRand_WhenUsingSameSeed(t *testing.T) {
+	// Arrange
+	rng := state.NewRNG(12345)
+	reference := rand.New(rand.NewSource(12345))
+
+	// Act & Assert
+	for i := 0; i < 100; i++ {
+		if got, want := rng.Rand().Intn(54), reference.Intn(54); got != want {
+			t.Fatalf("Value %d differs: got %d, want %d", i, got, want)
+		}
+	}
+}
+
+// TestShouldContinueStream_WhenRestoringRNGFromPosition tests resuming an RNG from seed and position
+func TestShouldContinueStream_WhenRestoringRNGFromPosition(t *testing.T) {
+	// Arrange
+	rng := state.NewRNG(7)
+	for i := 0; i < 25; i++ {
+		rng.Rand().Intn(10)
+	}
+
+	// Act
+	restored := &state.RNG{Seed: rng.Seed, Position: rng.Position}
+
+	// Assert
+	for i := 0; i < 25; i++ {
+		if got, want := restored.Rand().Int63(), rng.Rand().Int63(); got != want {
+			t.Fatalf("Value %d differs after restore: got %d, want %d", i, got, want)
+		}
+	}
+}
+
+// TestShouldProduceIdenticalGames_WhenReplayingSameSeedAndMoves tests determinism across reshuffles
+func TestShouldProduceIdenticalGames_WhenReplayingSameSeedAndMoves(t *testing.T) {
+	// Arrange
+	first := newSeededState(t)
+	second := newSeededState(t)
+	initialPosition := first.RNG.Position
+
+	// Act
+	playScripted(t, first, 120)
+	playScripted(t, second, 120)
+
+	// Assert
+	if first.RNG.Position == initialPosition {
+		t.Fatal("Expected the draw pile to be reshuffled during the game")
+	}
+
+	firstJSON, _ := first.ToJSON()
+	secondJSON, _ := second.ToJSON()
+	if !bytes.Equal(firstJSON, secondJSON) {
+		t.Error("Expected identical states for the same seed and moves")
+	}
+}
+
+// TestShouldNotDiverge_WhenRestoringStateFromJSON tests that a restored game continues like the original
+func TestShouldNotDiverge_WhenRestoringStateFromJSON(t *testing.T) {
+	// Arrange
+	original := newSeededState(t)
+	playScripted(t, original, 40)
+
+	data, err := original.ToJSON()
+	if err != nil {
+		t.Fatalf("Failed to serialize state: %v", err)
+	}
+	restored, err := state.FromJSON(data)
+	if err != nil {
+		t.Fatalf("Failed to deserialize state: %v", err)
+	}
+	initialPosition := original.RNG.Position
+
+	// Act
+	playScripted(t, original, 80)
+	playScripted(t, restored, 80)
+
+	// Assert
+	if original.RNG.Position == initialPosition {
+		t.Fatal("Expected the draw pile to be reshuffled after the round trip")
+	}
+
+	originalJSON, _ := original.ToJSON()
+	restoredJSON, _ := restored.ToJSON()
+	if !bytes.Equal(originalJSON, restoredJSON) {
+		t.Error("Expected the restored game to continue exactly like the original")
+	}
+}
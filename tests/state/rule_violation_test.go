@@ -0,0 +1,90 @@
+package state_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/djoufson/check-games-engine/card"
+	"github.com/djoufson/check-games-engine/state"
+)
+
+// TestShouldReturnRuleViolation_WhenPlayingOutOfTurn tests the violation reported for a move out of turn
+func TestShouldReturnRuleViolation_WhenPlayingOutOfTurn(t *testing.T) {
+	// Arrange
+	gameState, _, _ := setupCardPlayTest()
+	c := card.NewCard(card.Diamonds, card.Queen)
+
+	// Act
+	err := gameState.PlayCard("player2", c)
+
+	// Assert
+	if !errors.Is(err, state.ErrNotYourTurn) {
+		t.Fatalf("Expected ErrNotYourTurn, got %v", err)
+	}
+
+	var violation *state.RuleViolation
+	if !errors.As(err, &violation) {
+		t.Fatalf("Expected a *RuleViolation, got %T", err)
+	}
+
+	if violation.Code != state.CodeNotYourTurn {
+		t.Errorf("Expected code %s, got %s", state.CodeNotYourTurn, violation.Code)
+	}
+
+	if violation.Move.Type != state.MovePlayCard || violation.Move.PlayerID != "player2" || *violation.Move.Card != c {
+		t.Errorf("Expected the offending move to be recorded, got %+v", violation.Move)
+	}
+}
+
+// TestShouldReportSameViolation_WhenCheckingAndApplyingMove tests that CheckMove and the action agree
+func TestShouldReportSameViolation_WhenCheckingAndApplyingMove(t *testing.T) {
+	testCases := []struct {
+		name string
+		move state.Move
+		want error
+		code state.ViolationCode
+	}{
+		{"card not in hand", state.NewPlayCardMove("player1", card.NewCard(card.Clubs, card.Five)), state.ErrCardNotInHand, state.CodeCardNotInHand},
+		{"invalid play", state.NewPlayCardMove("player1", card.NewCard(card.Hearts, card.King)), state.ErrInvalidPlay, state.CodeInvalidPlay},
+		{"turn not locked", state.NewChangeSuitMove("player1", card.Hearts), state.ErrTurnNotLocked, state.CodeTurnNotLocked},
+		{"missing card", state.Move{Type: state.MovePlayCard, PlayerID: "player1"}, state.ErrMissingCard, state.CodeMalformedMove},
+		{"unknown move", state.Move{Type: "FOLD", PlayerID: "player1"}, state.ErrUnknownMove, state.CodeMalformedMove},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			gameState, _, _ := setupCardPlayTest()
+
+			// Act
+			checkErr := gameState.CheckMove(tc.move)
+			applyErr := gameState.Apply(tc.move)
+
+			// Assert
+			for _, err := range []error{checkErr, applyErr} {
+				var violation *state.RuleViolation
+				if !errors.As(err, &violation) {
+					t.Fatalf("Expected a *RuleViolation, got %v", err)
+				}
+
+				if !errors.Is(err, tc.want) || violation.Code != tc.code {
+					t.Errorf("Expected %v (%s), got %v (%s)", tc.want, tc.code, err, violation.Code)
+				}
+			}
+		})
+	}
+}
+
+// TestShouldReturnNilError_WhenMoveIsLegal tests that a legal move is not reported as a typed nil violation
+func TestShouldReturnNilError_WhenMoveIsLegal(t *testing.T) {
+	// Arrange
+	gameState, _, _ := setupCardPlayTest()
+
+	// Act
+	err := gameState.CheckMove(state.NewPlayCardMove("player1", card.NewCard(card.Spades, card.Ace)))
+
+	// Assert
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
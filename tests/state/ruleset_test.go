@@ -0,0 +1,186 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/djoufson/check-games-engine/card"
+	"github.com/djoufson/check-games-engine/rules"
+	"github.com/djoufson/check-games-engine/state"
+)
+
+// TestShouldUseRulesetPenalty_WhenPlayingAttackCard tests custom penalty sizes
+func TestShouldUseRulesetPenalty_WhenPlayingAttackCard(t *testing.T) {
+	// Arrange
+	gameState, _, _ := setupAttackChainTest()
+	rs := rules.Default()
+	rs.Penalties[card.Seven] = 3
+	rs.JokerPenalty = 5
+	gameState.Ruleset = &rs
+
+	// Act
+	gameState.PlayCard("player1", card.NewCard(card.Hearts, card.Seven))
+	gameState.PlayCard("player2", card.NewCard(card.Spades, card.Seven))
+	err := gameState.PlayCard("player1", card.NewRedJoker())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to play Joker: %v", err)
+	}
+
+	if gameState.AttackAmount != 11 {
+		t.Errorf("Expected attack amount to be 11, got %d", gameState.AttackAmount)
+	}
+}
+
+// TestShouldRejectCounterAttack_WhenAttacksCannotStack tests the stacking option
+func TestShouldRejectCounterAttack_WhenAttacksCannotStack(t *testing.T) {
+	// Arrange
+	gameState, _, player2 := setupAttackChainTest()
+	rs := rules.Default()
+	rs.StackAttacks = false
+	gameState.Ruleset = &rs
+	if err := gameState.PlayCard("player1", card.NewCard(card.Hearts, card.Seven)); err != nil {
+		t.Fatalf("Failed to play Seven: %v", err)
+	}
+	initialHandSize := len(player2.Hand)
+
+	// Act
+	err := gameState.PlayCard("player2", card.NewCard(card.Spades, card.Seven))
+
+	// Assert
+	if err == nil {
+		t.Error("Expected counter attack to be rejected when attacks cannot stack")
+	}
+
+	if err := gameState.DrawCard("player2"); err != nil {
+		t.Fatalf("Failed to draw penalty: %v", err)
+	}
+
+	if len(player2.Hand) != initialHandSize+2 {
+		t.Errorf("Expected player2 to draw 2 cards, got %d", len(player2.Hand)-initialHandSize)
+	}
+}
+
+// TestShouldSkipWithCustomRank_WhenRankIsAssignedSkip tests moving the skip effect to another rank
+func TestShouldSkipWithCustomRank_WhenRankIsAssignedSkip(t *testing.T) {
+	// Arrange
+	gameState, player1, _ := setupCardPlayTest()
+	rs := rules.Default()
+	delete(rs.Effects, card.Ace)
+	rs.Effects[card.King] = rules.Skip
+	gameState.Ruleset = &rs
+	player1.AddToHand(card.NewCard(card.Spades, card.King))
+
+	// Act
+	aceState := gameState.Clone()
+	aceErr := aceState.PlayCard("player1", card.NewCard(card.Spades, card.Ace))
+	err := gameState.PlayCard("player1", card.NewCard(card.Spades, card.King))
+
+	// Assert
+	if aceErr != nil || err != nil {
+		t.Fatalf("Failed to play cards: %v, %v", aceErr, err)
+	}
+
+	if aceState.CurrentPlayerID() != "player2" {
+		t.Errorf("Expected Ace to no longer skip, got %s", aceState.CurrentPlayerID())
+	}
+
+	if gameState.CurrentPlayerID() != "player1" {
+		t.Errorf("Expected King to skip player2, got %s", gameState.CurrentPlayerID())
+	}
+}
+
+// TestShouldDealRulesetHandSize_WhenInitialCardsIsNotSet tests the initial hand size from the ruleset
+func TestShouldDealRulesetHandSize_WhenInitialCardsIsNotSet(t *testing.T) {
+	// Arrange
+	rs := rules.Default()
+	rs.InitialCards = 4
+
+	// Act
+	gameState, err := state.New([]string{"player1", "player2"}, &state.GameOptions{
+		RandomSeed: 12345,
+		Ruleset:    &rs,
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to create new game: %v", err)
+	}
+
+	if len(gameState.FindPlayerByID("player1").Hand) != 4 {
+		t.Errorf("Expected hand size to be 4, got %d", len(gameState.FindPlayerByID("player1").Hand))
+	}
+}
+
+// TestShouldDealRulesetHandSize_WhenStartingFromDefaultOptions tests that the default options defer to the ruleset
+func TestShouldDealRulesetHandSize_WhenStartingFromDefaultOptions(t *testing.T) {
+	// Arrange
+	rs := rules.Default()
+	rs.InitialCards = 4
+	opts := state.DefaultOptions()
+	opts.Ruleset = &rs
+
+	// Act
+	gameState, err := state.New([]string{"player1", "player2"}, &opts)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to create new game: %v", err)
+	}
+
+	if len(gameState.FindPlayerByID("player1").Hand) != 4 {
+		t.Errorf("Expected hand size to be 4, got %d", len(gameState.FindPlayerByID("player1").Hand))
+	}
+
+	if gameState.Ruleset.InitialCards != 4 {
+		t.Errorf("Expected the saved ruleset to deal 4 cards, got %d", gameState.Ruleset.InitialCards)
+	}
+}
+
+// TestShouldRejectInvalidRuleset_WhenCreatingState tests ruleset validation on creation
+func TestShouldRejectInvalidRuleset_WhenCreatingState(t *testing.T) {
+	// Arrange
+	rs := rules.Default()
+	rs.Penalties[card.Seven] = 0
+
+	// Act
+	_, err := state.New([]string{"player1", "player2"}, &state.GameOptions{Ruleset: &rs})
+
+	// Assert
+	if err == nil {
+		t.Error("Expected error for an invalid ruleset")
+	}
+}
+
+// TestShouldPreserveRuleset_WhenRoundTrippingThroughJSON tests ruleset serialization
+func TestShouldPreserveRuleset_WhenRoundTrippingThroughJSON(t *testing.T) {
+	// Arrange
+	rs := rules.Default()
+	rs.Effects[card.Eight] = rules.Skip
+	rs.StackAttacks = false
+	gameState, err := state.New([]string{"player1", "player2"}, &state.GameOptions{Ruleset: &rs})
+	if err != nil {
+		t.Fatalf("Failed to create new game: %v", err)
+	}
+
+	// Act
+	data, err := gameState.ToJSON()
+	if err != nil {
+		t.Fatalf("Failed to serialize state: %v", err)
+	}
+	restored, err := state.FromJSON(data)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to deserialize state: %v", err)
+	}
+
+	restoredRules := restored.Rules()
+	if !restoredRules.IsSkip(card.NewCard(card.Hearts, card.Eight)) {
+		t.Error("Expected Eight to still skip after round trip")
+	}
+
+	if restoredRules.StackAttacks {
+		t.Error("Expected stacking to stay disabled after round trip")
+	}
+}
@@ -0,0 +1,203 @@
+package state_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/djoufson/check-games-engine/card"
+	"github.com/djoufson/check-games-engine/state"
+)
+
+// withoutCardIDs returns a copy of the state whose cards have no instance IDs,
+// as saved before version 3
+func withoutCardIDs(s *state.State) *state.State {
+	clone := s.Clone()
+	strip := func(cards []card.Card) {
+		for i := range cards {
+			cards[i] = cards[i].Face()
+		}
+	}
+	for _, p := range clone.Players {
+		strip(p.Hand)
+	}
+	strip(clone.DrawPile.Cards)
+	strip(clone.DiscardPile)
+	clone.TopCard = clone.TopCard.Face()
+	return clone
+}
+
+// legacyJSON rewrites a serialized state into the unversioned format of
+// version 1, with blocked_turn, a numeric direction and no card IDs
+func legacyJSON(t *testing.T, s *state.State) []byte {
+	t.Helper()
+
+	data, err := json.Marshal(withoutCardIDs(s))
+	if err != nil {
+		t.Fatalf("Failed to serialize state: %v", err)
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("Failed to decode state: %v", err)
+	}
+	fields["blocked_turn"] = fields["locked_turn"]
+	delete(fields, "locked_turn")
+	fields["direction"] = int(s.Direction)
+
+	legacy, err := json.Marshal(fields)
+	if err != nil {
+		t.Fatalf("Failed to serialize legacy state: %v", err)
+	}
+	return legacy
+}
+
+// TestShouldWriteVersion_WhenSerializing tests the envelope written by ToJSON
+func TestShouldWriteVersion_WhenSerializing(t *testing.T) {
+	// Arrange
+	gameState := setupValidStateTest(t)
+	gameState.ReverseDirection()
+
+	// Act
+	data, err := gameState.ToJSON()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to serialize state: %v", err)
+	}
+
+	var env struct {
+		Version int             `json:"version"`
+		State   json.RawMessage `json:"state"`
+	}
+	if err := json.Unmarshal(data, &env); err != nil {
+		t.Fatalf("Failed to decode envelope: %v", err)
+	}
+
+	if env.Version != state.SchemaVersion {
+		t.Errorf("Expected version %d, got %d", state.SchemaVersion, env.Version)
+	}
+
+	if !strings.Contains(string(env.State), `"direction":"COUNTER_CLOCKWISE"`) || !strings.Contains(string(env.State), `"locked_turn":false`) {
+		t.Errorf("Expected named direction and locked_turn, got %s", env.State)
+	}
+}
+
+// TestShouldMigrateLegacySave_WhenRestoringVersion1 tests loading a save from before versioning
+func TestShouldMigrateLegacySave_WhenRestoringVersion1(t *testing.T) {
+	// Arrange
+	gameState := setupValidStateTest(t)
+	gameState.ReverseDirection()
+	data := legacyJSON(t, gameState)
+
+	// Act
+	restored, err := state.FromJSON(data)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to load legacy save: %v", err)
+	}
+
+	if restored.Direction != state.CounterClockwise {
+		t.Errorf("Expected %s, got %s", state.CounterClockwise, restored.Direction)
+	}
+
+	if !withoutCardIDs(restored).Equal(withoutCardIDs(gameState)) {
+		t.Error("Expected the migrated state to equal the original")
+	}
+}
+
+// TestShouldNumberCards_WhenMigratingVersion2 tests that cards saved without IDs get unique ones
+func TestShouldNumberCards_WhenMigratingVersion2(t *testing.T) {
+	// Arrange
+	gameState := setupValidStateTest(t)
+	stateJSON, err := json.Marshal(withoutCardIDs(gameState))
+	if err != nil {
+		t.Fatalf("Failed to serialize state: %v", err)
+	}
+	data, _ := json.Marshal(map[string]any{"version": 2, "state": json.RawMessage(stateJSON)})
+
+	// Act
+	restored, err := state.FromJSON(data)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to load version 2 save: %v", err)
+	}
+
+	seen := make(map[int]bool)
+	for _, p := range restored.Players {
+		for _, c := range p.Hand {
+			if c.ID == 0 || seen[c.ID] {
+				t.Fatalf("Expected a unique ID on %v", c)
+			}
+			seen[c.ID] = true
+		}
+	}
+
+	if restored.TopCard.ID == 0 || restored.TopCard != restored.DiscardPile[len(restored.DiscardPile)-1] {
+		t.Errorf("Expected the numbered top card to be the last discard, got %+v", restored.TopCard)
+	}
+}
+
+// TestShouldRejectTamperedTopCard_WhenMigratingVersion2 tests that the migration does not repair a corrupt top card
+func TestShouldRejectTamperedTopCard_WhenMigratingVersion2(t *testing.T) {
+	// Arrange
+	gameState := withoutCardIDs(setupValidStateTest(t))
+	gameState.TopCard = gameState.Players[0].Hand[0]
+	stateJSON, err := json.Marshal(gameState)
+	if err != nil {
+		t.Fatalf("Failed to serialize state: %v", err)
+	}
+	data, _ := json.Marshal(map[string]any{"version": 2, "state": json.RawMessage(stateJSON)})
+
+	// Act
+	restored, err := state.FromJSON(data)
+
+	// Assert
+	if !errors.Is(err, state.ErrInvalidState) || restored != nil {
+		t.Errorf("Expected the tampered save to be rejected, got %v", err)
+	}
+}
+
+// TestShouldKeepLockedTurn_WhenMigratingVersion1 tests that blocked_turn becomes locked_turn
+func TestShouldKeepLockedTurn_WhenMigratingVersion1(t *testing.T) {
+	// Arrange
+	gameState, _, _ := setupSuitChangerTest()
+	if err := gameState.PlayCard("player1", card.NewCard(card.Clubs, card.Jack)); err != nil {
+		t.Fatalf("Failed to play Jack: %v", err)
+	}
+	gameState.Phase = ""
+	completeDrawPile(gameState)
+	data := legacyJSON(t, gameState)
+
+	// Act
+	restored, err := state.FromJSON(data)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to load legacy save: %v", err)
+	}
+
+	if !restored.LockedTurn || restored.CurrentPhase() != state.PhaseAwaitingSuitChoice {
+		t.Errorf("Expected a locked turn awaiting a suit, got %v in %s", restored.LockedTurn, restored.CurrentPhase())
+	}
+}
+
+// TestShouldRejectSave_WhenVersionIsUnknown tests strict version checks
+func TestShouldRejectSave_WhenVersionIsUnknown(t *testing.T) {
+	for _, version := range []int{0, state.SchemaVersion + 1} {
+		// Arrange
+		data := []byte(fmt.Sprintf(`{"version":%d,"state":{}}`, version))
+
+		// Act
+		_, err := state.FromJSON(data)
+
+		// Assert
+		if !errors.Is(err, state.ErrUnsupportedVersion) {
+			t.Errorf("Expected ErrUnsupportedVersion for version %d, got %v", version, err)
+		}
+	}
+}
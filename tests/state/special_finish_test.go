@@ -0,0 +1,124 @@
+package state_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/djoufson/check-games-engine/card"
+	"github.com/djoufson/check-games-engine/deck"
+	"github.com/djoufson/check-games-engine/player"
+	"github.com/djoufson/check-games-engine/rules"
+	"github.com/djoufson/check-games-engine/state"
+)
+
+// setupSpecialFinishTest creates a state where player1 holds only an Ace matching the top card
+func setupSpecialFinishTest(finish rules.FinishRule) *state.State {
+	rs := rules.Default()
+	rs.SpecialFinish = finish
+	rs.FinishPenalty = 3
+
+	player1 := player.New("player1")
+	player1.AddToHand(card.NewCard(card.Hearts, card.Ace))
+
+	player2 := player.New("player2")
+	player2.AddCardsToHand([]card.Card{
+		card.NewCard(card.Clubs, card.Five),
+		card.NewCard(card.Clubs, card.Six),
+	})
+
+	return &state.State{
+		Players:         []*player.Player{player1, player2},
+		ActivePlayers:   []string{player1.ID, player2.ID},
+		CurrentPlayerId: player1.ID,
+		Direction:       state.Clockwise,
+		DrawPile:        deck.New(),
+		DiscardPile:     []card.Card{card.NewCard(card.Hearts, card.Queen)},
+		TopCard:         card.NewCard(card.Hearts, card.Queen),
+		LastActiveSuit:  card.Hearts,
+		Ruleset:         &rs,
+	}
+}
+
+// TestShouldRejectLastCard_WhenFinishingOnSpecialCardIsForbidden tests the forbidden rule
+func TestShouldRejectLastCard_WhenFinishingOnSpecialCardIsForbidden(t *testing.T) {
+	// Arrange
+	gameState := setupSpecialFinishTest(rules.FinishForbidden)
+	ace := card.NewCard(card.Hearts, card.Ace)
+
+	// Act
+	err := gameState.PlayCard("player1", ace)
+
+	// Assert
+	if !errors.Is(err, state.ErrSpecialFinish) {
+		t.Fatalf("Expected ErrSpecialFinish, got %v", err)
+	}
+
+	counts := countMoves(gameState.LegalMoves("player1"))
+	if counts[state.MovePlayCard] != 0 || counts[state.MoveDrawCard] != 1 {
+		t.Errorf("Expected only a draw to be legal, got %v", counts)
+	}
+
+	player1 := gameState.FindPlayerByID("player1")
+	if playable := player1.PlayableCardsUnder(gameState.Rules(), gameState.TopCard, gameState.ActiveSuit(), false); len(playable) != 0 {
+		t.Errorf("Expected no playable cards, got %v", playable)
+	}
+}
+
+// TestShouldAllowSpecialCard_WhenItIsNotTheLastCard tests that the rule only applies to the last card
+func TestShouldAllowSpecialCard_WhenItIsNotTheLastCard(t *testing.T) {
+	// Arrange
+	gameState := setupSpecialFinishTest(rules.FinishForbidden)
+	gameState.FindPlayerByID("player1").AddToHand(card.NewCard(card.Hearts, card.Nine))
+
+	// Act
+	err := gameState.PlayCard("player1", card.NewCard(card.Hearts, card.Ace))
+
+	// Assert
+	if err != nil {
+		t.Errorf("Expected the Ace to be playable, got %v", err)
+	}
+}
+
+// TestShouldDrawPenalty_WhenFinishingOnSpecialCardIsPenalized tests the penalty rule
+func TestShouldDrawPenalty_WhenFinishingOnSpecialCardIsPenalized(t *testing.T) {
+	// Arrange
+	gameState := setupSpecialFinishTest(rules.FinishPenalty)
+
+	// Act
+	err := gameState.PlayCard("player1", card.NewCard(card.Hearts, card.Ace))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to play Ace: %v", err)
+	}
+
+	if !gameState.IsPlayerActive("player1") {
+		t.Error("Expected player1 to stay in the game")
+	}
+
+	if size := gameState.FindPlayerByID("player1").HandSize(); size != 3 {
+		t.Errorf("Expected player1 to draw 3 penalty cards, got %d", size)
+	}
+
+	if gameState.IsGameOver() {
+		t.Error("Expected the game to go on")
+	}
+}
+
+// TestShouldFinish_WhenFinishingOnSpecialCardIsAllowed tests the default rule
+func TestShouldFinish_WhenFinishingOnSpecialCardIsAllowed(t *testing.T) {
+	// Arrange
+	gameState := setupSpecialFinishTest(rules.FinishAllowed)
+
+	// Act
+	err := gameState.PlayCard("player1", card.NewCard(card.Hearts, card.Ace))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to play Ace: %v", err)
+	}
+
+	if gameState.IsPlayerActive("player1") {
+		t.Error("Expected player1 to be out of the game")
+	}
+}
@@ -0,0 +1,100 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/djoufson/check-games-engine/card"
+	"github.com/djoufson/check-games-engine/deck"
+	"github.com/djoufson/check-games-engine/player"
+	"github.com/djoufson/check-games-engine/state"
+)
+
+// setupStandingsTest creates a three player state where player2 goes out before player1
+func setupStandingsTest() *state.State {
+	player1 := player.New("player1")
+	player1.AddCardsToHand([]card.Card{
+		card.NewCard(card.Hearts, card.Five),
+		card.NewCard(card.Hearts, card.Nine),
+	})
+
+	player2 := player.New("player2")
+	player2.AddToHand(card.NewCard(card.Hearts, card.Six))
+
+	player3 := player.New("player3")
+	player3.AddCardsToHand([]card.Card{
+		card.NewCard(card.Hearts, card.Eight),
+		card.NewCard(card.Hearts, card.Ten),
+		card.NewCard(card.Hearts, card.King),
+	})
+
+	return &state.State{
+		Players:         []*player.Player{player1, player2, player3},
+		ActivePlayers:   []string{player1.ID, player2.ID, player3.ID},
+		CurrentPlayerId: player1.ID,
+		Direction:       state.Clockwise,
+		DrawPile:        deck.New(),
+		DiscardPile:     []card.Card{card.NewCard(card.Hearts, card.Queen)},
+		TopCard:         card.NewCard(card.Hearts, card.Queen),
+		LastActiveSuit:  card.Hearts,
+	}
+}
+
+// TestShouldRecordPlacements_WhenPlayersFinish tests the finish order and turn numbers
+func TestShouldRecordPlacements_WhenPlayersFinish(t *testing.T) {
+	// Arrange
+	gameState := setupStandingsTest()
+	moves := []state.Move{
+		state.NewPlayCardMove("player1", card.NewCard(card.Hearts, card.Five)),
+		state.NewPlayCardMove("player2", card.NewCard(card.Hearts, card.Six)),
+		state.NewPlayCardMove("player3", card.NewCard(card.Hearts, card.Eight)),
+		state.NewPlayCardMove("player1", card.NewCard(card.Hearts, card.Nine)),
+	}
+
+	// Act
+	for _, m := range moves {
+		if err := gameState.Apply(m); err != nil {
+			t.Fatalf("Failed to apply %v: %v", m, err)
+		}
+	}
+
+	// Assert
+	want := []state.Placement{
+		{PlayerID: "player2", Place: 1, Turn: 2},
+		{PlayerID: "player1", Place: 2, Turn: 4},
+		{PlayerID: "player3", Place: 3, Turn: 4},
+	}
+	standings := gameState.Standings()
+	if len(standings) != len(want) {
+		t.Fatalf("Expected %d placements, got %v", len(want), standings)
+	}
+	for i := range want {
+		if standings[i] != want[i] {
+			t.Errorf("Expected placement %v, got %v", want[i], standings[i])
+		}
+	}
+
+	winners := gameState.GetWinner()
+	if len(winners) != 2 || winners[0] != "player2" || winners[1] != "player1" {
+		t.Errorf("Expected winners in finishing order [player2 player1], got %v", winners)
+	}
+}
+
+// TestShouldCountTurn_WhenSuitIsDeclared tests that a Jack and its suit declaration form one turn
+func TestShouldCountTurn_WhenSuitIsDeclared(t *testing.T) {
+	// Arrange
+	gameState, _, _ := setupSuitChangerTest()
+
+	// Act
+	gameState.PlayCard("player1", card.NewCard(card.Clubs, card.Jack))
+	turnAfterJack := gameState.CurrentTurn()
+	gameState.ChangeSuit("player1", card.Hearts)
+
+	// Assert
+	if turnAfterJack != 1 {
+		t.Errorf("Expected turn 1 while the suit is pending, got %d", turnAfterJack)
+	}
+
+	if gameState.CurrentTurn() != 2 {
+		t.Errorf("Expected turn 2 after the suit declaration, got %d", gameState.CurrentTurn())
+	}
+}
@@ -1,6 +1,8 @@
 package state_test
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/djoufson/check-games-engine/state"
@@ -100,3 +102,56 @@ func TestShouldReturnError_WhenCreatingStateWithTooFewPlayers(t *testing.T) {
 		t.Error("Expected error with only one player")
 	}
 }
+
+// TestShouldUseSeveralDecks_WhenTableIsLarge tests the player-count default and explicit shoe sizes
+func TestShouldUseSeveralDecks_WhenTableIsLarge(t *testing.T) {
+	tests := []struct {
+		players int
+		decks   int
+		want    int
+	}{
+		{players: 2, want: 1},
+		{players: 5, want: 1},
+		{players: 6, want: 2},
+		{players: 11, want: 3},
+		{players: 3, decks: 2, want: 2},
+	}
+
+	for _, tt := range tests {
+		// Arrange
+		playerIDs := make([]string, tt.players)
+		for i := range playerIDs {
+			playerIDs[i] = fmt.Sprintf("player%d", i+1)
+		}
+
+		// Act
+		gameState, err := state.New(playerIDs, &state.GameOptions{RandomSeed: 7, Decks: tt.decks})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Failed to create a game for %d players: %v", tt.players, err)
+		}
+
+		if gameState.Decks != tt.want {
+			t.Errorf("Expected %d decks for %d players, got %d", tt.want, tt.players, gameState.Decks)
+		}
+
+		if err := gameState.Validate(); err != nil {
+			t.Errorf("Expected a valid %d-deck game, got %v", tt.want, err)
+		}
+	}
+}
+
+// TestShouldReturnError_WhenShoeCannotDealEveryHand tests a shoe too small for the table
+func TestShouldReturnError_WhenShoeCannotDealEveryHand(t *testing.T) {
+	// Arrange
+	playerIDs := []string{"player1", "player2", "player3", "player4", "player5", "player6", "player7", "player8"}
+
+	// Act
+	_, err := state.New(playerIDs, &state.GameOptions{Decks: 1})
+
+	// Assert
+	if !errors.Is(err, state.ErrNotEnoughCards) {
+		t.Errorf("Expected ErrNotEnoughCards, got %v", err)
+	}
+}
@@ -0,0 +1,174 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/djoufson/check-games-engine/card"
+	"github.com/djoufson/check-games-engine/deck"
+	"github.com/djoufson/check-games-engine/player"
+	"github.com/djoufson/check-games-engine/state"
+)
+
+// setupTransparentCardTest creates a game state where player1 can play a 2 on the Queen of Hearts
+func setupTransparentCardTest() (*state.State, *player.Player, *player.Player) {
+	player1 := player.New("player1")
+	player1.AddCardsToHand([]card.Card{
+		card.NewCard(card.Clubs, card.Two),
+		card.NewCard(card.Clubs, card.Jack),
+		card.NewCard(card.Clubs, card.Nine),
+	})
+
+	player2 := player.New("player2")
+	player2.AddCardsToHand([]card.Card{
+		card.NewCard(card.Hearts, card.Five),  // Matches the Queen underneath
+		card.NewCard(card.Clubs, card.Five),   // Matches the 2 only
+		card.NewCard(card.Spades, card.Queen), // Matches the Queen by rank
+		card.NewCard(card.Spades, card.Two),   // Transparent
+	})
+
+	topCard := card.NewCard(card.Hearts, card.Queen)
+	gameState := &state.State{
+		Players:         []*player.Player{player1, player2},
+		ActivePlayers:   []string{player1.ID, player2.ID},
+		CurrentPlayerId: player1.ID,
+		Direction:       state.Clockwise,
+		DrawPile:        deck.New(),
+		DiscardPile:     []card.Card{topCard},
+		TopCard:         topCard,
+		LastActiveSuit:  card.Hearts,
+	}
+
+	return gameState, player1, player2
+}
+
+// TestShouldLookThroughTwo_WhenComputingEffectiveTopCard tests the effective top card after a 2
+func TestShouldLookThroughTwo_WhenComputingEffectiveTopCard(t *testing.T) {
+	// Arrange
+	gameState, _, _ := setupTransparentCardTest()
+
+	// Act
+	err := gameState.PlayCard("player1", card.NewCard(card.Clubs, card.Two))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to play Two: %v", err)
+	}
+
+	if gameState.TopCard.Rank != card.Two {
+		t.Errorf("Expected top card to be the Two, got %v", gameState.TopCard)
+	}
+
+	effective := gameState.EffectiveTopCard()
+	if effective.Suit != card.Hearts || effective.Rank != card.Queen {
+		t.Errorf("Expected effective top card to be Queen of Hearts, got %v", effective)
+	}
+
+	if gameState.ActiveSuit() != card.Hearts {
+		t.Errorf("Expected active suit to stay Hearts, got %v", gameState.ActiveSuit())
+	}
+}
+
+// TestShouldMatchCardUnderneath_WhenPlayingOnTwo tests that plays are validated against the card under a 2
+func TestShouldMatchCardUnderneath_WhenPlayingOnTwo(t *testing.T) {
+	// Arrange
+	gameState, _, _ := setupTransparentCardTest()
+	if err := gameState.PlayCard("player1", card.NewCard(card.Clubs, card.Two)); err != nil {
+		t.Fatalf("Failed to play Two: %v", err)
+	}
+
+	// Act
+	heartsErr := gameState.Clone().PlayCard("player2", card.NewCard(card.Hearts, card.Five))
+	clubsErr := gameState.Clone().PlayCard("player2", card.NewCard(card.Clubs, card.Five))
+	queenErr := gameState.Clone().PlayCard("player2", card.NewCard(card.Spades, card.Queen))
+
+	// Assert
+	if heartsErr != nil {
+		t.Errorf("Expected Five of Hearts to match the Queen under the Two, got %v", heartsErr)
+	}
+
+	if clubsErr == nil {
+		t.Error("Expected Five of Clubs to be rejected since the Two is transparent")
+	}
+
+	if queenErr != nil {
+		t.Errorf("Expected Queen of Spades to match the Queen under the Two by rank, got %v", queenErr)
+	}
+}
+
+// TestShouldLookThroughStackedTwos_WhenComputingEffectiveTopCard tests several 2s on top of each other
+func TestShouldLookThroughStackedTwos_WhenComputingEffectiveTopCard(t *testing.T) {
+	// Arrange
+	gameState, _, _ := setupTransparentCardTest()
+
+	// Act
+	if err := gameState.PlayCard("player1", card.NewCard(card.Clubs, card.Two)); err != nil {
+		t.Fatalf("Failed to play first Two: %v", err)
+	}
+	if err := gameState.PlayCard("player2", card.NewCard(card.Spades, card.Two)); err != nil {
+		t.Fatalf("Failed to play second Two: %v", err)
+	}
+
+	// Assert
+	effective := gameState.EffectiveTopCard()
+	if effective.Suit != card.Hearts || effective.Rank != card.Queen {
+		t.Errorf("Expected effective top card to be Queen of Hearts, got %v", effective)
+	}
+}
+
+// TestShouldKeepDeclaredSuit_WhenTwoIsPlayedOnJack tests that a 2 does not clear a declared suit
+func TestShouldKeepDeclaredSuit_WhenTwoIsPlayedOnJack(t *testing.T) {
+	// Arrange
+	gameState, _, _ := setupTransparentCardTest()
+	if err := gameState.PlayCard("player1", card.NewCard(card.Clubs, card.Jack)); err != nil {
+		t.Fatalf("Failed to play Jack: %v", err)
+	}
+	if err := gameState.ChangeSuit("player1", card.Diamonds); err != nil {
+		t.Fatalf("Failed to change suit: %v", err)
+	}
+
+	// Act
+	err := gameState.PlayCard("player2", card.NewCard(card.Spades, card.Two))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to play Two on Jack: %v", err)
+	}
+
+	if gameState.ActiveSuit() != card.Diamonds {
+		t.Errorf("Expected declared Diamonds to stay active through the Two, got %v", gameState.ActiveSuit())
+	}
+}
+
+// TestShouldKeepCardUnderTwo_WhenReshufflingDiscardPile tests that reshuffling keeps the effective top card
+func TestShouldKeepCardUnderTwo_WhenReshufflingDiscardPile(t *testing.T) {
+	// Arrange
+	gameState, _, _ := setupTransparentCardTest()
+	gameState.DiscardPile = []card.Card{
+		card.NewCard(card.Diamonds, card.Three),
+		card.NewCard(card.Hearts, card.Queen),
+		card.NewCard(card.Clubs, card.Two),
+	}
+	gameState.TopCard = card.NewCard(card.Clubs, card.Two)
+	gameState.DrawPile = &deck.Deck{}
+
+	// Act
+	err := gameState.ReshuffleDiscardPile()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to reshuffle: %v", err)
+	}
+
+	if len(gameState.DiscardPile) != 2 {
+		t.Fatalf("Expected discard pile to keep the Two and the Queen, got %v", gameState.DiscardPile)
+	}
+
+	if gameState.DrawPile.Count() != 1 {
+		t.Errorf("Expected 1 card to be reshuffled, got %d", gameState.DrawPile.Count())
+	}
+
+	effective := gameState.EffectiveTopCard()
+	if effective.Suit != card.Hearts || effective.Rank != card.Queen {
+		t.Errorf("Expected effective top card to be Queen of Hearts, got %v", effective)
+	}
+}
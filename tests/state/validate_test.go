@@ -0,0 +1,139 @@
+package state_test
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/djoufson/check-games-engine/card"
+	"github.com/djoufson/check-games-engine/deck"
+	"github.com/djoufson/check-games-engine/state"
+)
+
+// completeDrawPile replaces the draw pile of a hand-built state with every
+// card that is not in a hand or on the discard pile, so the state validates
+func completeDrawPile(s *state.State) {
+	used := slices.Clone(s.DiscardPile)
+	for _, p := range s.Players {
+		used = append(used, p.Hand...)
+	}
+
+	s.DrawPile = &deck.Deck{}
+	for _, c := range deck.New().Cards {
+		if !slices.ContainsFunc(used, c.Matches) {
+			s.DrawPile.AddToBottom(c)
+		}
+	}
+}
+
+// setupValidStateTest creates a new game that passes validation
+func setupValidStateTest(t *testing.T) *state.State {
+	t.Helper()
+
+	gameState, err := state.New([]string{"player1", "player2", "player3"}, &state.GameOptions{RandomSeed: 42})
+	if err != nil {
+		t.Fatalf("Failed to create new game: %v", err)
+	}
+
+	return gameState
+}
+
+// TestShouldPassValidation_WhenGameIsPlayedNormally tests that legal play keeps the state valid
+func TestShouldPassValidation_WhenGameIsPlayedNormally(t *testing.T) {
+	// Arrange
+	gameState := setupValidStateTest(t)
+
+	// Act & Assert
+	for turn := 0; turn < 200 && !gameState.IsGameOver(); turn++ {
+		if err := gameState.Validate(); err != nil {
+			t.Fatalf("Expected a valid state on turn %d, got %v", turn, err)
+		}
+		if err := gameState.Apply(gameState.LegalMoves(gameState.CurrentPlayerID())[0]); err != nil {
+			t.Fatalf("Failed to apply legal move on turn %d: %v", turn, err)
+		}
+	}
+
+	if err := gameState.Validate(); err != nil {
+		t.Errorf("Expected a valid final state, got %v", err)
+	}
+}
+
+// TestShouldFailValidation_WhenStateIsCorrupt tests each invariant
+func TestShouldFailValidation_WhenStateIsCorrupt(t *testing.T) {
+	tests := []struct {
+		name    string
+		corrupt func(s *state.State)
+	}{
+		{"missing card", func(s *state.State) { s.DrawPile.Draw() }},
+		{"duplicate card", func(s *state.State) { s.Players[0].AddToHand(s.Players[1].Hand[0]) }},
+		{"unknown card", func(s *state.State) { s.Players[0].AddToHand(card.NewCard("STARS", card.Ace)) }},
+		{"top card", func(s *state.State) { s.TopCard = s.Players[0].Hand[0] }},
+		{"inactive current player", func(s *state.State) { s.CurrentPlayerId = "nobody" }},
+		{"unknown active player", func(s *state.State) { s.ActivePlayers = append(s.ActivePlayers, "nobody") }},
+		{"duplicate player", func(s *state.State) { s.Players[1].ID = s.Players[0].ID }},
+		{"attack without amount", func(s *state.State) { s.InAttackChain = true }},
+		{"amount without attack", func(s *state.State) { s.AttackAmount = 2 }},
+		{"unknown phase", func(s *state.State) { s.Phase = "BOGUS" }},
+		{"finished with players left", func(s *state.State) { s.Phase = state.PhaseFinished }},
+		{"locked turn outside suit choice", func(s *state.State) { s.LockedTurn = true }},
+		{"attack outside attack response", func(s *state.State) { s.InAttackChain, s.AttackAmount = true, 2 }},
+		{"suit choice without locked turn", func(s *state.State) { s.Phase = state.PhaseAwaitingSuitChoice }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			gameState := setupValidStateTest(t)
+			tt.corrupt(gameState)
+
+			// Act
+			err := gameState.Validate()
+
+			// Assert
+			if !errors.Is(err, state.ErrInvalidState) {
+				t.Errorf("Expected ErrInvalidState, got %v", err)
+			}
+		})
+	}
+}
+
+// TestShouldRejectTamperedSave_WhenRestoringFromJSON tests that FromJSON validates the state
+func TestShouldRejectTamperedSave_WhenRestoringFromJSON(t *testing.T) {
+	// Arrange
+	gameState := setupValidStateTest(t)
+	gameState.Players[0].AddToHand(card.NewRedJoker())
+	data, err := gameState.ToJSON()
+	if err != nil {
+		t.Fatalf("Failed to serialize state: %v", err)
+	}
+
+	// Act
+	restored, err := state.FromJSON(data)
+
+	// Assert
+	if !errors.Is(err, state.ErrInvalidState) || restored != nil {
+		t.Errorf("Expected the tampered save to be rejected, got %v", err)
+	}
+}
+
+// TestShouldRejectTamperedPhase_WhenRestoringFromJSON tests that a save claiming the game is over is rejected
+func TestShouldRejectTamperedPhase_WhenRestoringFromJSON(t *testing.T) {
+	// Arrange
+	data, err := setupValidStateTest(t).ToJSON()
+	if err != nil {
+		t.Fatalf("Failed to serialize state: %v", err)
+	}
+	tampered := bytes.Replace(data, []byte(`"phase":"AWAITING_PLAY"`), []byte(`"phase":"FINISHED"`), 1)
+	if bytes.Equal(tampered, data) {
+		t.Fatal("Expected the save to record its phase")
+	}
+
+	// Act
+	restored, err := state.FromJSON(tampered)
+
+	// Assert
+	if !errors.Is(err, state.ErrInvalidState) || restored != nil {
+		t.Errorf("Expected the tampered save to be rejected, got %v", err)
+	}
+}
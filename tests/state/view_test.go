@@ -0,0 +1,94 @@
+package state_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/djoufson/check-games-engine/card"
+	"github.com/djoufson/check-games-engine/state"
+)
+
+// TestShouldHideOpponentHands_WhenViewingAsPlayer tests that a player only sees their own hand
+func TestShouldHideOpponentHands_WhenViewingAsPlayer(t *testing.T) {
+	// Arrange
+	gameState, player1, player2 := setupAttackChainTest()
+
+	// Act
+	view, err := gameState.ViewFor("player1")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Failed to get view: %v", err)
+	}
+
+	if view.ViewerID != "player1" || len(view.Hand) != player1.HandSize() {
+		t.Errorf("Expected player1's %d cards, got %v", player1.HandSize(), view.Hand)
+	}
+
+	if len(view.Players) != 2 || view.Players[1].ID != "player2" || view.Players[1].HandSize != player2.HandSize() {
+		t.Errorf("Expected player2 with %d cards, got %+v", player2.HandSize(), view.Players)
+	}
+
+	if view.DrawPileCount != gameState.DrawPile.Count() {
+		t.Errorf("Expected a draw pile of %d cards, got %d", gameState.DrawPile.Count(), view.DrawPileCount)
+	}
+
+	data, err := json.Marshal(view)
+	if err != nil {
+		t.Fatalf("Failed to serialize view: %v", err)
+	}
+	for _, c := range player2.Hand {
+		hidden, _ := json.Marshal(c)
+		if strings.Contains(string(data), string(hidden)) {
+			t.Errorf("Expected player2's %v to stay hidden", c)
+		}
+	}
+	if strings.Contains(string(data), "draw_pile\"") {
+		t.Error("Expected the draw pile order to stay hidden")
+	}
+}
+
+// TestShouldShowPublicInformation_WhenViewingAsSpectator tests the spectator view during an attack
+func TestShouldShowPublicInformation_WhenViewingAsSpectator(t *testing.T) {
+	// Arrange
+	gameState, _, _ := setupAttackChainTest()
+	if err := gameState.PlayCard("player1", card.NewCard(card.Hearts, card.Seven)); err != nil {
+		t.Fatalf("Failed to attack: %v", err)
+	}
+
+	// Act
+	view := gameState.SpectatorView()
+
+	// Assert
+	if view.ViewerID != "" || view.Hand != nil {
+		t.Errorf("Expected a spectator view without a hand, got %s with %v", view.ViewerID, view.Hand)
+	}
+
+	if !view.InAttackChain || view.AttackAmount != 2 || view.Phase != state.PhaseAwaitingAttackResponse {
+		t.Errorf("Expected an attack of 2, got %v with %d in %s", view.InAttackChain, view.AttackAmount, view.Phase)
+	}
+
+	if view.TopCard != card.NewCard(card.Hearts, card.Seven) || view.ActiveSuit != card.Hearts {
+		t.Errorf("Expected the Seven of Hearts on top, got %v in %s", view.TopCard, view.ActiveSuit)
+	}
+
+	if view.CurrentPlayerId != "player2" {
+		t.Errorf("Expected player2's turn, got %s", view.CurrentPlayerId)
+	}
+}
+
+// TestShouldRejectView_WhenPlayerIsUnknown tests viewing the game as an unknown player
+func TestShouldRejectView_WhenPlayerIsUnknown(t *testing.T) {
+	// Arrange
+	gameState, _, _ := setupAttackChainTest()
+
+	// Act
+	_, err := gameState.ViewFor("nobody")
+
+	// Assert
+	if !errors.Is(err, state.ErrPlayerNotFound) {
+		t.Errorf("Expected ErrPlayerNotFound, got %v", err)
+	}
+}